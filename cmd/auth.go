@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/auth"
+	"github.com/redjax/go-mygithub/internal/config"
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/redjax/go-mygithub/internal/tokenstore"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// Cobra flags
+var (
+	withToken   bool
+	deviceLogin bool
+	oauthScopes []string
+)
+
+// Init "auth" subcommand
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage stored Github credentials",
+}
+
+// Init "auth login" subcommand
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Store a Github access token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var token string
+		var err error
+
+		if deviceLogin {
+			// Authorize in the browser with the OAuth device flow
+			token, err = deviceFlowToken()
+		} else {
+			// Read token from stdin or prompt
+			token, err = readToken(withToken)
+		}
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			return fmt.Errorf("no token provided")
+		}
+
+		// Validate token before storing it
+		user, _, err := ghclient.New(viper.GetString("api_url"), auth.StaticTokenSource(token), nil).GetUser()
+		if err != nil {
+			return fmt.Errorf("error validating token: %w", err)
+		}
+
+		return saveToken(token, user.Login)
+	},
+}
+
+// Init "auth logout" subcommand
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the stored Github access token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := newTokenStore()
+		if err != nil {
+			return err
+		}
+
+		account := tokenAccount()
+		if err := store.Delete(account); err != nil {
+			if errors.Is(err, tokenstore.ErrNotFound) {
+				return fmt.Errorf("no stored token for profile %q", account)
+			}
+			return fmt.Errorf("error removing token: %w", err)
+		}
+
+		fmt.Printf("Removed stored token for profile %q.\n", account)
+
+		return nil
+	},
+}
+
+// Init "auth status" subcommand
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the authenticated user, token scopes & expiry",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Github Apps can't call /user, show the installation token instead
+		if viper.GetString("app_id") != "" {
+			return githubAppStatus()
+		}
+
+		token, source, err := resolveGithubToken()
+		if err != nil {
+			return err
+		}
+		if token == "" {
+			return fmt.Errorf("not logged in (use 'mygithub auth login', --access-token, GITHUB_TOKEN env, or config file)")
+		}
+
+		// Validate token & read scopes, expiry, & rate limit
+		client := ghclient.New(viper.GetString("api_url"), auth.StaticTokenSource(token), nil)
+		result, err := client.Preflight(nil, true)
+		if err != nil {
+			return fmt.Errorf("error checking token: %w", err)
+		}
+
+		fmt.Printf("Profile:    %s\n", tokenAccount())
+		fmt.Printf("Token from: %s\n", source)
+		fmt.Printf("Logged in:  %s\n", result.Login)
+
+		// Classic PATs report scopes, fine-grained PATs do not
+		if result.ScopesKnown && len(result.Scopes) > 0 {
+			fmt.Printf("Scopes:     %s\n", strings.Join(result.Scopes, ", "))
+		} else if result.ScopesKnown {
+			fmt.Println("Scopes:     (none)")
+		} else {
+			fmt.Println("Scopes:     (none reported, fine-grained token?)")
+		}
+
+		if !result.Expires.IsZero() {
+			days := int(time.Until(result.Expires).Hours() / 24)
+			fmt.Printf("Expires:    %s (in %d days)\n", result.Expires.Format(time.RFC3339), days)
+		} else {
+			fmt.Println("Expires:    never")
+		}
+
+		reset := time.Unix(result.RateLimit.Reset, 0)
+		fmt.Printf("Rate limit: %d/%d remaining, resets at %s\n", result.RateLimit.Remaining, result.RateLimit.Limit, reset.Format(time.Kitchen))
+
+		return nil
+	},
+}
+
+// Mint a Github App installation token & report when it expires
+func githubAppStatus() error {
+	tokens, err := githubAppTokenSource()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tokens.Token(); err != nil {
+		return fmt.Errorf("error checking Github App credentials: %w", err)
+	}
+
+	fmt.Printf("Profile:      %s\n", tokenAccount())
+	fmt.Printf("Github App:   %s\n", tokens.AppID)
+	fmt.Printf("Installation: %d\n", tokens.InstallationID)
+	fmt.Printf("Expires:      %s (refreshed automatically)\n", tokens.Expires().Format(time.RFC3339))
+
+	return nil
+}
+
+// "auth" CLI entrypoint
+func init() {
+	// Add auth subcommand to root CLI
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+	authCmd.AddCommand(authStatusCmd)
+
+	// Read token from stdin instead of prompting
+	authLoginCmd.Flags().BoolVar(&withToken, "with-token", false, "Read token from standard input")
+
+	// OAuth device flow
+	authLoginCmd.Flags().BoolVar(&deviceLogin, "device", false, "Log in with the OAuth device flow in a browser")
+	authLoginCmd.Flags().String("client-id", "", "OAuth App client ID for the device flow")
+	authLoginCmd.Flags().StringSliceVar(&oauthScopes, "scopes", []string{"repo", "read:org", "read:user"}, "OAuth scopes to request with --device")
+	authLoginCmd.MarkFlagsMutuallyExclusive("device", "with-token")
+	viper.BindPFlag("oauth_client_id", authLoginCmd.Flags().Lookup("client-id"))
+	viper.SetDefault("oauth_url", constants.GH_WEB_URL)
+
+	// Token store backend
+	authCmd.PersistentFlags().String("store", "auto", "Token store backend (auto, keyring, file)")
+	viper.BindPFlag("token_store", authCmd.PersistentFlags().Lookup("store"))
+	viper.SetDefault("token_store", "auto")
+}
+
+// Load Github PAT from viper or the token store, "" if there isn't one
+func loadGithubToken() (string, error) {
+	token, _, err := resolveGithubToken()
+
+	return token, err
+}
+
+// Find the Github PAT & report where it came from, "" if there isn't one.
+// Priority: flag > env > config > token store
+func resolveGithubToken() (string, string, error) {
+	token := viper.GetString("access_token")
+	if token != "" {
+		switch {
+		case rootCmd.PersistentFlags().Changed("access-token"):
+			return token, "flag", nil
+		case os.Getenv("GITHUB_TOKEN") != "" || os.Getenv("GH_TOKEN") != "":
+			return token, "env", nil
+		default:
+			return token, "config", nil
+		}
+	}
+
+	store, err := newTokenStore()
+	if err != nil {
+		return "", "", err
+	}
+
+	// A wrong passphrase or broken keyring isn't the same as not being logged in
+	token, location, err := tokenstore.Lookup(store, tokenAccount())
+	if errors.Is(err, tokenstore.ErrNotFound) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("error reading token from %s token store: %w", store.Name(), err)
+	}
+
+	return token, location, nil
+}
+
+// Create the configured token store
+func newTokenStore() (tokenstore.Store, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenstore.New(viper.GetString("token_store"), dir)
+}
+
+// Account name tokens are stored under, one per profile
+func tokenAccount() string {
+	if p := viper.GetString("profile"); p != "" {
+		return p
+	}
+
+	return "default"
+}
+
+// Save a token to the token store for the current profile
+func saveToken(token string, login string) error {
+	store, err := newTokenStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Set(tokenAccount(), token); err != nil {
+		return fmt.Errorf("error storing token: %w", err)
+	}
+
+	_, location, err := tokenstore.Lookup(store, tokenAccount())
+	if err != nil {
+		return fmt.Errorf("error checking stored token: %w", err)
+	}
+	fmt.Printf("Logged in as %s. Token stored in %s for profile %q.\n", login, location, tokenAccount())
+
+	return nil
+}
+
+// Get a token by authorizing in the browser with the OAuth device flow
+func deviceFlowToken() (string, error) {
+	clientID := viper.GetString("oauth_client_id")
+	if clientID == "" {
+		return "", fmt.Errorf("OAuth client ID not provided (use --client-id or oauth_client_id in config file)")
+	}
+
+	flow := auth.NewDeviceFlow(viper.GetString("oauth_url"), clientID, oauthScopes)
+
+	// Request a user code
+	code, err := flow.RequestCode()
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Printf("First copy your one-time code: %s\n", code.UserCode)
+	fmt.Printf("Then open %s in your browser to authorize mygithub.\n", code.VerificationURI)
+	fmt.Println("Waiting for authorization...")
+
+	// Wait for the user to authorize
+	token, err := flow.PollToken(code)
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// Read a token from stdin, prompting without echo when attached to a terminal
+func readToken(fromStdin bool) (string, error) {
+	fd := int(os.Stdin.Fd())
+
+	if !fromStdin && term.IsTerminal(fd) {
+		fmt.Print("Paste your Github access token: ")
+		raw, err := term.ReadPassword(fd)
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("error reading token: %w", err)
+		}
+		return strings.TrimSpace(string(raw)), nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("error reading token from stdin: %w", err)
+	}
+
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/auth"
+	"github.com/redjax/go-mygithub/internal/cache"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/spf13/viper"
+)
+
+// Create a Github API client with the HTTP cache enabled
+func newCachedGithubClient() (*ghclient.Client, error) {
+	// Get HTTP cache client
+	httpClient := cache.NewCachingClient(
+		viper.GetString("cache_dir"),
+		viper.GetInt("cache_duration"),
+	)
+
+	return newGithubClient(httpClient)
+}
+
+// Create a Github API client authenticated with a PAT or as a Github App
+func newGithubClient(httpClient *http.Client) (*ghclient.Client, error) {
+	tokens, err := githubTokenSource()
+	if err != nil {
+		return nil, err
+	}
+
+	client := ghclient.New(viper.GetString("api_url"), tokens, httpClient)
+
+	// Github Enterprise serves GraphQL from /api/graphql rather than under the REST URL
+	if graphqlURL := viper.GetString("graphql_url"); graphqlURL != "" {
+		client.GraphQLURL = graphqlURL
+	}
+
+	return client, nil
+}
+
+// Check the token works & has the scopes a command needs before a long run
+func runPreflight(client *ghclient.Client, requiredScopes ...string) error {
+	if viper.GetBool("skip_preflight") {
+		return nil
+	}
+
+	// Bypass the HTTP cache so a revoked token isn't hidden by a cached response
+	uncached := *client
+	uncached.HTTP = http.DefaultClient
+
+	// Github Apps can't call /user
+	isApp := viper.GetString("app_id") != ""
+
+	result, err := uncached.Preflight(requiredScopes, !isApp)
+	if err != nil {
+		return fmt.Errorf("pre-flight check failed: %w", err)
+	}
+
+	who := result.Login
+	if isApp {
+		who = fmt.Sprintf("Github App %s", viper.GetString("app_id"))
+	}
+	fmt.Fprintf(os.Stderr, "Authenticated as %s (%d/%d API requests remaining)\n", who, result.RateLimit.Remaining, result.RateLimit.Limit)
+
+	if len(requiredScopes) > 0 && !result.ScopesKnown {
+		fmt.Fprintf(os.Stderr, "  Can't verify scopes for this token type, make sure it has: %s\n", strings.Join(requiredScopes, ", "))
+	}
+
+	return nil
+}
+
+// Build the token source for API requests.
+// A configured Github App takes priority over a PAT.
+func githubTokenSource() (auth.TokenSource, error) {
+	if viper.GetString("app_id") != "" {
+		return githubAppTokenSource()
+	}
+
+	// Load token from flag, env, config, or token store
+	token, err := loadGithubToken()
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return nil, fmt.Errorf("GitHub access token not provided (use --access-token, GITHUB_TOKEN env, config file, or 'mygithub auth login')")
+	}
+
+	return auth.StaticTokenSource(token), nil
+}
+
+// Build a Github App installation token source from config
+func githubAppTokenSource() (*auth.AppTokenSource, error) {
+	keyPath := viper.GetString("app_private_key")
+	if keyPath == "" {
+		return nil, fmt.Errorf("Github App private key not provided (use --app-private-key or app_private_key in config file)")
+	}
+
+	installationID := viper.GetInt64("app_installation_id")
+	if installationID == 0 {
+		return nil, fmt.Errorf("Github App installation ID not provided (use --app-installation-id or app_installation_id in config file)")
+	}
+
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Github App private key: %w", err)
+	}
+
+	return auth.NewAppTokenSource(viper.GetString("api_url"), viper.GetString("app_id"), installationID, keyPEM)
+}
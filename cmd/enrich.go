@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/enrich"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	enrichOnly      []string
+	enrichReason    string
+	enrichWorkers   int
+	enrichForce     bool
+	enrichLimit     int
+	enrichReserve   int
+	enrichReadmeDir string
+)
+
+// Tracking reasons "enrich --reason" accepts, "all" for every stored repository
+var enrichReasons = []string{Github.TrackStarred, Github.TrackOwned, Github.TrackCollaborator, Github.TrackOrg, Github.TrackWatching, "all"}
+
+// Init "enrich" subcommand
+var enrichCmd = &cobra.Command{
+	Use:   "enrich",
+	Short: "Fetch extra data for stored repositories",
+	Long: fmt.Sprintf(`Run enrichers over stored repositories: each one calls a per-repository
+API endpoint & stores the result. Enrichers: %s.
+
+Runs are incremental: a repository is only enriched again once it's pushed to
+(releases are also refreshed daily). Workers share one rate limit, spaced by
+--request-sleep, & the run stops when fewer than --reserve requests remain.
+What's left is picked up by the next run.`, strings.Join(enrich.Names(), ", ")),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !slices.Contains(enrichReasons, enrichReason) {
+			return fmt.Errorf("unknown reason %q (expected %s)", enrichReason, strings.Join(enrichReasons, ", "))
+		}
+
+		enrichers, err := enrich.Select(enrichOnly)
+		if err != nil {
+			return err
+		}
+		for _, e := range enrichers {
+			if r, ok := e.(*enrich.Readme); ok {
+				r.Dir = enrichReadmeDir
+			}
+		}
+
+		reason := enrichReason
+		if reason == "all" {
+			reason = ""
+		}
+
+		return runEnrichers(os.Stdout, enrichers, reason, enrich.Options{
+			Workers: enrichWorkers,
+			Force:   enrichForce,
+			Limit:   enrichLimit,
+			Reserve: enrichReserve,
+		})
+	},
+}
+
+// "enrich" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(enrichCmd)
+
+	enrichCmd.Flags().StringSliceVar(&enrichOnly, "only", nil, "Comma-separated enrichers to run (default: all)")
+	enrichCmd.Flags().StringVar(&enrichReason, "reason", Github.TrackStarred, "Enrich repositories tracked for this reason: "+strings.Join(enrichReasons, ", "))
+	enrichCmd.Flags().IntVar(&enrichWorkers, "workers", enrich.DefaultWorkers, "Number of concurrent requests")
+	enrichCmd.Flags().BoolVar(&enrichForce, "force", false, "Enrich every repository, even if unchanged since the last run")
+	enrichCmd.Flags().IntVar(&enrichLimit, "limit", 0, "Enrich at most N repositories per enricher this run (0 for all)")
+	enrichCmd.Flags().IntVar(&enrichReserve, "reserve", enrich.DefaultReserve, "Stop when fewer API requests than this remain")
+	enrichCmd.Flags().StringVar(&enrichReadmeDir, "readme-dir", "", "Store READMEs in a directory instead of the database")
+}
+
+// Run enrichers over stored repositories tracked for reason, or all if reason is "", & print a summary to w.
+// Fails if any repository failed, see enrichRepositories to only stop on rate limits & database errors.
+func runEnrichers(w io.Writer, enrichers []enrich.Enricher, reason string, opts enrich.Options) error {
+	results, err := enrichRepositories(w, enrichers, reason, opts)
+	if err != nil {
+		return err
+	}
+	if failed := failedRepositories(results); failed > 0 {
+		return fmt.Errorf("%d repositories failed", failed)
+	}
+
+	return nil
+}
+
+// Run enrichers like runEnrichers, but return per-enricher results instead of failing on per-repository errors
+func enrichRepositories(w io.Writer, enrichers []enrich.Enricher, reason string, opts enrich.Options) ([]enrich.Result, error) {
+	// Initialize database
+	dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+	if err != nil {
+		return nil, fmt.Errorf("error initializing database: %w", err)
+	}
+
+	repos, err := db.LoadRepositories(dbConn, reason)
+	if err != nil {
+		return nil, fmt.Errorf("error loading repositories from database: %w", err)
+	}
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories in database (run 'mygithub starred get --save-db' first)")
+	}
+
+	// Create Github API client with HTTP cache
+	client, err := newCachedGithubClient()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := runPreflight(client); err != nil {
+		return nil, err
+	}
+
+	opts.RequestSleep = time.Duration(viper.GetInt("request_sleep")) * time.Second
+	results, runErr := enrich.Run(client, dbConn, repos, enrichers, opts)
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%s: %d enriched, %d up to date", r.Enricher, r.Enriched, r.UpToDate)
+		if r.Failed > 0 {
+			fmt.Fprintf(w, ", %d failed", r.Failed)
+		}
+		if r.Remaining > 0 {
+			fmt.Fprintf(w, ", %d left for the next run", r.Remaining)
+		}
+		fmt.Fprintln(w)
+	}
+
+	return results, runErr
+}
+
+// Count the repositories that failed across enricher results
+func failedRepositories(results []enrich.Result) int {
+	failed := 0
+	for _, r := range results {
+		failed += r.Failed
+	}
+
+	return failed
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/feed"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	feedFormat     string
+	feedOutputFile string
+	feedLimit      int
+	feedTitle      string
+	feedLink       string
+)
+
+// Init "feed" subcommand
+var feedCmd = &cobra.Command{
+	Use:   "feed",
+	Short: "Generate feeds from stored data",
+}
+
+// Init "feed generate" subcommand
+var feedGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Write an Atom or RSS feed of new releases & newly starred repositories",
+	Long: `Write a feed of the newest releases & starred repositories in the database,
+for subscribing in a feed reader.
+
+Entry IDs are built from Github's release & repository IDs, so they never
+change & readers only show what's new. Regenerating from the same data
+gives the same file, & the file is only rewritten when an entry changes.
+
+Keep the data current with "mygithub starred get --save-db" &
+"mygithub starred releases".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(feedFormat, feed.FormatAtom, feed.FormatRSS); err != nil {
+			return err
+		}
+		if feedLimit < 1 {
+			return fmt.Errorf("--limit must be at least 1")
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		releases, repoNames, err := db.LoadLatestReleases(dbConn, feedLimit)
+		if err != nil {
+			return fmt.Errorf("error loading releases from database: %w", err)
+		}
+		starred, err := db.LoadRecentlyStarred(dbConn, feedLimit)
+		if err != nil {
+			return fmt.Errorf("error loading starred repositories from database: %w", err)
+		}
+
+		f := feed.Build(feedTitle, feedLink, releases, repoNames, starred, feedLimit)
+
+		var buf bytes.Buffer
+		if feedFormat == feed.FormatRSS {
+			err = f.WriteRSS(&buf)
+		} else {
+			err = f.WriteAtom(&buf)
+		}
+		if err != nil {
+			return err
+		}
+
+		if feedOutputFile == "-" {
+			_, err := os.Stdout.Write(buf.Bytes())
+			return err
+		}
+
+		// Count entries the last generated feed didn't have
+		previous := feed.ReadEntryIDs(feedOutputFile)
+		newEntries := 0
+		for _, e := range f.Entries {
+			if !previous[e.ID] {
+				newEntries++
+			}
+		}
+
+		if existing, err := os.ReadFile(feedOutputFile); err == nil && bytes.Equal(existing, buf.Bytes()) {
+			fmt.Printf("Feed is up to date (%d entries): %s\n", len(f.Entries), feedOutputFile)
+			return nil
+		}
+
+		if err := os.WriteFile(feedOutputFile, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %w", feedOutputFile, err)
+		}
+		fmt.Printf("Feed with %d entries (%d new) saved to: %s\n", len(f.Entries), newEntries, feedOutputFile)
+
+		return nil
+	},
+}
+
+// "feed" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(feedCmd)
+	feedCmd.AddCommand(feedGenerateCmd)
+
+	feedGenerateCmd.Flags().StringVar(&feedFormat, "format", feed.FormatAtom, "Feed format: atom or rss")
+	feedGenerateCmd.Flags().StringVarP(&feedOutputFile, "output", "o", "feed.xml", "Feed file to write (- for stdout)")
+	feedGenerateCmd.Flags().IntVar(&feedLimit, "limit", 50, "Maximum number of entries in the feed")
+	feedGenerateCmd.Flags().StringVar(&feedTitle, "title", "Starred repositories", "Feed title")
+	feedGenerateCmd.Flags().StringVar(&feedLink, "link", "https://github.com", "Link to the feed's website")
+}
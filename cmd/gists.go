@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// Cobra flags
+var (
+	gistsDir   string
+	gistsForce bool
+)
+
+// Metadata file written to each gist's backup folder
+const gistMetadataFile = "gist.json"
+
+// Init "gists" subcommand
+var gistsCmd = &cobra.Command{
+	Use:   "gists",
+	Short: "Operations on your gists",
+}
+
+// Init "gists backup" subcommand
+var gistsBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up your gists & their files",
+	Long: `Download every gist & its files to a directory, the database, or both.
+
+With --dir, each gist gets a folder named by its ID holding its files & a
+gist.json with its metadata. With --save-db, gists & file contents are saved
+to the gist tables. Gists whose updated_at hasn't changed since the last
+backup are skipped, unless --force is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if gistsDir == "" && !saveDB {
+			return fmt.Errorf("nowhere to back up to (use --dir, --save-db, or both)")
+		}
+
+		// Create Github API client without the HTTP cache, so updated_at is current
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		// Validate token before listing gists
+		if err := runPreflight(client, "gist"); err != nil {
+			return err
+		}
+
+		// List gists, without file contents
+		gists, err := ghclient.FetchAllPages[Github.Gist](client, apiURL(constants.GH_GISTS_ENDPOINT+"?per_page=100"), viper.GetInt("request_sleep"))
+		if err != nil {
+			return fmt.Errorf("error fetching gists: %w", err)
+		}
+		fmt.Printf("Found %d gists.\n", len(gists))
+
+		// Load updated_at from the last database backup
+		var stored map[string]time.Time
+		var dbConn *gorm.DB
+		if saveDB {
+			dbConn, err = db.InitDB(viper.GetString("db_dsn"))
+			if err != nil {
+				return fmt.Errorf("error initializing database: %w", err)
+			}
+			stored, err = db.LoadGistUpdatedAt(dbConn)
+			if err != nil {
+				return fmt.Errorf("error loading gists from database: %w", err)
+			}
+		}
+
+		saved, unchanged, failed := 0, 0, 0
+		for _, listed := range gists {
+			upToDate := !gistsForce &&
+				(!saveDB || stored[listed.ID].Equal(listed.UpdatedAt)) &&
+				(gistsDir == "" || gistDirUpdatedAt(listed.ID).Equal(listed.UpdatedAt))
+			if upToDate {
+				unchanged++
+				continue
+			}
+
+			if saved+failed > 0 {
+				// Wait before next request
+				time.Sleep(time.Duration(viper.GetInt("request_sleep")) * time.Second)
+			}
+
+			gist, err := fetchGistContents(client, listed.ID)
+			if err == nil && gistsDir != "" {
+				err = writeGistDir(*gist)
+			}
+			if err == nil && saveDB {
+				err = db.SaveGist(dbConn, *gist)
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  %s: %v\n", listed.ID, err)
+				failed++
+				continue
+			}
+
+			fmt.Printf("Backed up %s (%d files)\n", gistLabel(*gist), len(gist.Files))
+			saved++
+		}
+
+		fmt.Printf("\n%d gists backed up, %d unchanged.\n", saved, unchanged)
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d gists failed", failed, len(gists))
+		}
+
+		return nil
+	},
+}
+
+// "gists" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(gistsCmd)
+	gistsCmd.AddCommand(gistsBackupCmd)
+
+	gistsBackupCmd.Flags().StringVar(&gistsDir, "dir", "", "Directory to back up gists to, one folder per gist")
+	gistsBackupCmd.Flags().BoolVar(&saveDB, "save-db", false, "Save gists & file contents to the database")
+	gistsBackupCmd.Flags().BoolVar(&gistsForce, "force", false, "Back up every gist, even if unchanged since the last backup")
+}
+
+// Get a gist with the full contents of every file
+func fetchGistContents(client *ghclient.Client, id string) (*Github.Gist, error) {
+	gist, err := client.GetGist(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files over 1MB are truncated in the API response
+	for name, file := range gist.Files {
+		if !file.Truncated {
+			continue
+		}
+		content, err := client.GetGistFileContent(file)
+		if err != nil {
+			return nil, err
+		}
+		file.Content = content
+		file.Truncated = false
+		gist.Files[name] = file
+	}
+
+	return gist, nil
+}
+
+// Write a gist's files & metadata to <dir>/<id>/, replacing a previous backup
+func writeGistDir(gist Github.Gist) error {
+	// The folder is removed & recreated, so make sure the ID can't escape --dir
+	if gist.ID == "" || gist.ID != filepath.Base(gist.ID) || gist.ID == "." || gist.ID == ".." {
+		return fmt.Errorf("unsafe gist ID %q", gist.ID)
+	}
+	dir := filepath.Join(gistsDir, gist.ID)
+
+	// Files renamed or removed from the gist don't linger
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("error clearing %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+
+	metadata := gist
+	metadata.Files = map[string]Github.GistFile{}
+	for name, file := range gist.Files {
+		// Gist filenames can't contain slashes, but don't trust that
+		filename := filepath.Base(file.Filename)
+		if filename == "." || filename == ".." || filename == gistMetadataFile {
+			return fmt.Errorf("unsafe filename %q", file.Filename)
+		}
+
+		if err := os.WriteFile(filepath.Join(dir, filename), []byte(file.Content), 0644); err != nil {
+			return fmt.Errorf("error writing %s: %w", filename, err)
+		}
+
+		// Contents are in the files, keep metadata small
+		file.Content = ""
+		metadata.Files[name] = file
+	}
+
+	return writeJSONFile(filepath.Join(dir, gistMetadataFile), metadata)
+}
+
+// updated_at of a gist's directory backup, or zero if it hasn't been backed up
+func gistDirUpdatedAt(id string) time.Time {
+	raw, err := os.ReadFile(filepath.Join(gistsDir, id, gistMetadataFile))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var gist Github.Gist
+	if err := json.Unmarshal(raw, &gist); err != nil {
+		return time.Time{}
+	}
+
+	return gist.UpdatedAt
+}
+
+// Gist description, or its ID if it has none
+func gistLabel(gist Github.Gist) string {
+	if gist.Description != nil && *gist.Description != "" {
+		return fmt.Sprintf("%s (%s)", truncate(*gist.Description, 50), gist.ID)
+	}
+
+	return gist.ID
+}
@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/redjax/go-mygithub/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	notifRepos         []string
+	notifReasons       []string
+	notifTypes         []string
+	notifParticipating bool
+	notifOlderThan     int
+	notifAll           bool
+	notifDone          bool
+	notifFormat        string
+	notifSinceDays     int
+)
+
+// Init "notifications" subcommand
+var notificationsCmd = &cobra.Command{
+	Use:     "notifications",
+	Aliases: []string{"notifs"},
+	Short:   "Triage your notifications inbox",
+}
+
+// Init "notifications list" subcommand
+var notificationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List notifications",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		// Validate token before paging through notifications
+		if err := runPreflight(client, "notifications"); err != nil {
+			return err
+		}
+
+		all, err := fetchNotifications(client, notifAll)
+		if err != nil {
+			return err
+		}
+
+		if saveDB {
+			// Initialize database
+			dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+			if err != nil {
+				return fmt.Errorf("error initializing database: %w", err)
+			}
+
+			// Save everything fetched, so reports aren't limited by filters
+			if err := db.SaveNotifications(dbConn, all); err != nil {
+				return fmt.Errorf("error saving notifications to database: %w", err)
+			}
+		}
+
+		matched := filterNotifications(all, time.Now())
+		printNotifications(matched)
+		fmt.Printf("\n%d of %d notifications matched.\n", len(matched), len(all))
+		if saveDB {
+			fmt.Printf("Saved %d notifications to database.\n", len(all))
+		}
+
+		return nil
+	},
+}
+
+// Init "notifications read" subcommand
+var notificationsReadCmd = &cobra.Command{
+	Use:   "read [thread-id]...",
+	Short: "Mark notifications as read (or done) by ID or by rule",
+	Long: `Mark notification threads as read, or as done with --done.
+
+Pass thread IDs (from "notifications list"), or rules to match unread
+notifications: --repo, --reason, --type, --participating, --older-than.
+Matches are shown for confirmation first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		action, verb := "Mark %d notifications as read?", "Marked read"
+		mark := (*ghclient.Client).MarkThreadRead
+		if notifDone {
+			action, verb = "Mark %d notifications as done?", "Marked done"
+			mark = (*ghclient.Client).MarkThreadDone
+		}
+
+		return triageNotifications(args, action, verb, mark)
+	},
+}
+
+// Init "notifications mute" subcommand
+var notificationsMuteCmd = &cobra.Command{
+	Use:   "mute [thread-id]...",
+	Short: "Mute & mark notifications read by ID or by rule",
+	Long: `Mute notification threads, so further activity doesn't notify you, and
+mark them as read. Takes thread IDs or the same rules as "notifications read".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return triageNotifications(args, "Mute %d notifications?", "Muted", func(c *ghclient.Client, id string) error {
+			if err := c.MuteThread(id); err != nil {
+				return err
+			}
+			return c.MarkThreadRead(id)
+		})
+	},
+}
+
+// Init "notifications report" subcommand
+var notificationsReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Report notification volume per repository",
+	Long: `Count stored notifications per repository, with unread counts & reasons.
+
+Notifications are stored by "notifications list --save-db" & when triaged
+by rule, so run those regularly for a full picture.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(notifFormat, output.FormatTable, output.FormatJSON, output.FormatCSV); err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		since := time.Now().AddDate(0, 0, -notifSinceDays)
+		notifications, err := db.LoadNotifications(dbConn, since)
+		if err != nil {
+			return fmt.Errorf("error loading notifications from database: %w", err)
+		}
+
+		volume := report.BuildNotificationVolume(notifications)
+
+		switch notifFormat {
+		case output.FormatJSON:
+			return output.WriteJSON(os.Stdout, volume)
+		case output.FormatCSV:
+			return output.WriteCSV(os.Stdout, report.NotificationVolumeCSVHeader, report.NotificationVolumeCSVRows(volume))
+		}
+
+		t := output.NewTable(os.Stdout)
+		fmt.Fprintln(t, "REPOSITORY\tTOTAL\tUNREAD\tLAST\tREASONS")
+		for _, v := range volume {
+			fmt.Fprintf(t, "%s\t%d\t%d\t%s\t%s\n", v.FullName, v.Total, v.Unread, v.LastUpdated.Local().Format("2006-01-02"), v.ReasonSummary())
+		}
+		t.Flush()
+		fmt.Printf("\n%d notifications in %d repositories over the last %d days.\n", len(notifications), len(volume), notifSinceDays)
+
+		return nil
+	},
+}
+
+// "notifications" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(notificationsCmd)
+	notificationsCmd.AddCommand(notificationsListCmd)
+	notificationsCmd.AddCommand(notificationsReadCmd)
+	notificationsCmd.AddCommand(notificationsMuteCmd)
+	notificationsCmd.AddCommand(notificationsReportCmd)
+
+	// Filters & rules
+	for _, c := range []*cobra.Command{notificationsListCmd, notificationsReadCmd, notificationsMuteCmd} {
+		c.Flags().StringSliceVar(&notifRepos, "repo", nil, "Only notifications from these owner/repo names (comma-separated)")
+		c.Flags().StringSliceVar(&notifReasons, "reason", nil, "Only these reasons, i.e. mention, review_requested, subscribed, ci_activity (comma-separated)")
+		c.Flags().StringSliceVar(&notifTypes, "type", nil, "Only these subject types, i.e. Issue, PullRequest, Release, CheckSuite (comma-separated)")
+		c.Flags().BoolVar(&notifParticipating, "participating", false, "Only threads you're participating in or mentioned on")
+		c.Flags().IntVar(&notifOlderThan, "older-than", 0, "Only notifications last updated more than this many days ago")
+	}
+	notificationsListCmd.Flags().BoolVar(&notifAll, "all", false, "Include notifications already marked read")
+	notificationsListCmd.Flags().BoolVar(&saveDB, "save-db", false, "Save fetched notifications to the database for reports")
+
+	// Triage options
+	notificationsReadCmd.Flags().BoolVar(&notifDone, "done", false, "Mark as done, removing them from the inbox, instead of read")
+	for _, c := range []*cobra.Command{notificationsReadCmd, notificationsMuteCmd} {
+		c.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Don't ask for confirmation")
+		c.Flags().BoolVar(&dryRun, "dry-run", false, "Only show matching notifications")
+	}
+
+	notificationsReportCmd.Flags().StringVar(&notifFormat, "format", output.FormatTable, "Output format: table, json, or csv")
+	notificationsReportCmd.Flags().IntVar(&notifSinceDays, "days", 30, "Only count notifications updated in this many days")
+}
+
+// Fetch notifications, unread only unless all is set
+func fetchNotifications(client *ghclient.Client, all bool) ([]Github.Notification, error) {
+	query := url.Values{}
+	query.Set("per_page", "50")
+	if all {
+		query.Set("all", "true")
+	}
+	if notifParticipating {
+		query.Set("participating", "true")
+	}
+
+	notifications, err := ghclient.FetchAllPages[Github.Notification](client, apiURL(constants.GH_NOTIFICATIONS_ENDPOINT+"?"+query.Encode()), viper.GetInt("request_sleep"))
+	if err != nil {
+		return nil, fmt.Errorf("error fetching notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// Apply --repo, --reason, --type, & --older-than filters
+func filterNotifications(notifications []Github.Notification, now time.Time) []Github.Notification {
+	olderThan := now.AddDate(0, 0, -notifOlderThan)
+	matches := func(values []string, v string) bool {
+		return len(values) == 0 || slices.ContainsFunc(values, func(s string) bool {
+			return strings.EqualFold(strings.TrimSpace(s), v)
+		})
+	}
+
+	var matched []Github.Notification
+	for _, n := range notifications {
+		if !matches(notifRepos, n.Repository.FullName) || !matches(notifReasons, n.Reason) || !matches(notifTypes, n.Subject.Type) {
+			continue
+		}
+		if notifOlderThan > 0 && n.UpdatedAt.After(olderThan) {
+			continue
+		}
+		matched = append(matched, n)
+	}
+
+	return matched
+}
+
+// Whether any rule flag was given
+func notificationRulesGiven() bool {
+	return len(notifRepos) > 0 || len(notifReasons) > 0 || len(notifTypes) > 0 || notifParticipating || notifOlderThan > 0
+}
+
+// Apply an action to notification threads given by ID, or unread threads matching rules
+func triageNotifications(args []string, question string, verb string, action func(*ghclient.Client, string) error) error {
+	if len(args) > 0 && notificationRulesGiven() {
+		return fmt.Errorf("pass thread IDs or rules, not both")
+	}
+	if len(args) == 0 && !notificationRulesGiven() {
+		return fmt.Errorf("no thread IDs or rules given (use --repo, --reason, --type, --participating, or --older-than)")
+	}
+
+	// Create Github API client without the HTTP cache, so unread state is current
+	client, err := newGithubClient(nil)
+	if err != nil {
+		return err
+	}
+
+	// Validate token before changing notifications
+	if err := runPreflight(client, "notifications"); err != nil {
+		return err
+	}
+
+	ids := args
+	var matched []Github.Notification
+	if len(args) == 0 {
+		unread, err := fetchNotifications(client, false)
+		if err != nil {
+			return err
+		}
+
+		matched = filterNotifications(unread, time.Now())
+		if len(matched) == 0 {
+			fmt.Printf("No notifications matched out of %d unread.\n", len(unread))
+			return nil
+		}
+
+		printNotifications(matched)
+		fmt.Printf("\n%d of %d unread notifications matched.\n", len(matched), len(unread))
+
+		ids = make([]string, len(matched))
+		for i, n := range matched {
+			ids[i] = n.ID
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	if len(matched) > 0 && !assumeYes && !confirm(fmt.Sprintf(question, len(ids))) {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	// Initialize database
+	dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+	if err != nil {
+		return fmt.Errorf("error initializing database: %w", err)
+	}
+
+	// Keep triaged threads for reports
+	if err := db.SaveNotifications(dbConn, matched); err != nil {
+		return fmt.Errorf("error saving notifications to database: %w", err)
+	}
+
+	var done []string
+	for i, id := range ids {
+		if i > 0 {
+			// Wait before next request
+			time.Sleep(time.Duration(viper.GetInt("request_sleep")) * time.Second)
+		}
+
+		if err := action(client, id); err != nil {
+			fmt.Fprintf(os.Stderr, "  %s: %v\n", id, err)
+			continue
+		}
+		done = append(done, id)
+	}
+
+	if err := db.MarkNotificationsRead(dbConn, done); err != nil {
+		return fmt.Errorf("error updating notifications in database: %w", err)
+	}
+
+	fmt.Printf("%s %d notifications.\n", verb, len(done))
+	if failed := len(ids) - len(done); failed > 0 {
+		return fmt.Errorf("%d of %d notifications failed", failed, len(ids))
+	}
+
+	return nil
+}
+
+// Print notifications as a table, unread threads marked with *
+func printNotifications(notifications []Github.Notification) {
+	t := output.NewTable(os.Stdout)
+	fmt.Fprintln(t, "\tID\tREPOSITORY\tTYPE\tREASON\tUPDATED\tTITLE")
+
+	for _, n := range notifications {
+		unread := ""
+		if n.Unread {
+			unread = "*"
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", unread, n.ID, n.Repository.FullName, n.Subject.Type, n.Reason, n.UpdatedAt.Local().Format("2006-01-02 15:04"), truncate(n.Subject.Title, 60))
+	}
+
+	t.Flush()
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/redjax/go-mygithub/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	reportFormat      string
+	reportOutputFile  string
+	reportStaleMonths int
+)
+
+// Init "org" subcommand
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Operations on organizations",
+}
+
+// Init "org report" subcommand
+var orgReportCmd = &cobra.Command{
+	Use:   "report <org>",
+	Short: "Inventory an organization's repositories",
+	Long: `Fetch all of an organization's repositories & report on their hygiene:
+visibility, archived repositories, default branches, license coverage,
+repositories without topics or a description, and time since last push.
+
+The table format prints a summary, json includes the summary & every
+repository, and csv has one row per repository.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(reportFormat, output.FormatTable, output.FormatJSON, output.FormatCSV); err != nil {
+			return err
+		}
+
+		// Create Github API client with HTTP cache
+		client, err := newCachedGithubClient()
+		if err != nil {
+			return err
+		}
+
+		// Validate token before paging through all repositories
+		if err := runPreflight(client); err != nil {
+			return err
+		}
+
+		// Fetch every repository in the organization
+		endpoint := fmt.Sprintf(constants.GH_ORG_REPOS_ENDPOINT, url.PathEscape(args[0])) + "?type=all&per_page=100"
+		repos, err := ghclient.FetchAllPages[Github.Repository](client, apiURL(endpoint), viper.GetInt("request_sleep"))
+		if err != nil {
+			return fmt.Errorf("error fetching repositories for %s: %w", args[0], err)
+		}
+		if len(repos) == 0 {
+			return fmt.Errorf("no repositories returned for %s", args[0])
+		}
+
+		inventory := report.BuildOrgInventory(args[0], repos, reportStaleMonths, time.Now())
+
+		// Write the report
+		w, err := output.Create(reportOutputFile)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+
+		switch reportFormat {
+		case output.FormatJSON:
+			err = output.WriteJSON(w, inventory)
+		case output.FormatCSV:
+			err = output.WriteCSV(w, report.OrgRepoCSVHeader, inventory.CSVRows())
+		default:
+			printOrgInventory(w, inventory)
+		}
+		if err != nil {
+			return err
+		}
+
+		if reportOutputFile != "" && reportOutputFile != "-" {
+			fmt.Printf("Report saved to: %s\n", reportOutputFile)
+		}
+
+		return nil
+	},
+}
+
+// "org" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(orgCmd)
+	orgCmd.AddCommand(orgReportCmd)
+
+	orgReportCmd.Flags().StringVar(&reportFormat, "format", output.FormatTable, "Output format: table, json, or csv")
+	orgReportCmd.Flags().StringVarP(&reportOutputFile, "output", "o", "", "Write the report to a file (default: stdout)")
+	orgReportCmd.Flags().IntVar(&reportStaleMonths, "stale-months", 12, "Report unarchived repositories not pushed to in this many months")
+}
+
+// Print an organization inventory summary as tables
+func printOrgInventory(w io.Writer, inv report.OrgInventory) {
+	pct := func(n int) string {
+		return fmt.Sprintf("%d (%.0f%%)", n, float64(n)*100/float64(inv.Total))
+	}
+
+	fmt.Fprintf(w, "Repository inventory for %s (%d repositories)\n\n", inv.Org, inv.Total)
+
+	t := output.NewTable(w)
+	for _, v := range report.SortedCounts(inv.Visibility) {
+		fmt.Fprintf(t, "Visibility %s\t%s\n", v, pct(inv.Visibility[v]))
+	}
+	fmt.Fprintf(t, "Archived\t%s\n", pct(inv.Archived))
+	fmt.Fprintf(t, "Forks\t%s\n", pct(inv.Forks))
+	fmt.Fprintf(t, "With a license\t%s\n", pct(inv.Licensed))
+	fmt.Fprintf(t, "Without topics\t%s\n", pct(len(inv.NoTopics)))
+	fmt.Fprintf(t, "Without a description\t%s\n", pct(len(inv.NoDescription)))
+	fmt.Fprintf(t, "Stale (no push in %d months)\t%s\n", inv.StaleMonths, pct(len(inv.Stale)))
+	t.Flush()
+
+	fmt.Fprintln(w, "\nLast push:")
+	t = output.NewTable(w)
+	for _, bucket := range report.LastPushBuckets {
+		fmt.Fprintf(t, "  %s\t%d\n", bucket, inv.LastPushBucket[bucket])
+	}
+	t.Flush()
+
+	fmt.Fprintln(w, "\nDefault branches:")
+	t = output.NewTable(w)
+	for _, branch := range report.SortedCounts(inv.DefaultBranch) {
+		fmt.Fprintf(t, "  %s\t%d\n", branch, inv.DefaultBranch[branch])
+	}
+	t.Flush()
+
+	fmt.Fprintln(w, "\nLicenses:")
+	t = output.NewTable(w)
+	for _, license := range report.SortedCounts(inv.Licenses) {
+		fmt.Fprintf(t, "  %s\t%d\n", license, inv.Licenses[license])
+	}
+	fmt.Fprintf(t, "  none\t%d\n", len(inv.NoLicense))
+	t.Flush()
+
+	// Repositories needing attention
+	for _, section := range []struct {
+		title string
+		names []string
+	}{
+		{"Without a license", inv.NoLicense},
+		{"Without topics", inv.NoTopics},
+		{"Without a description", inv.NoDescription},
+		{fmt.Sprintf("Stale, no push in %d months", inv.StaleMonths), inv.Stale},
+	} {
+		if len(section.names) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s (%d):\n  %s\n", section.title, len(section.names), strings.Join(section.names, "\n  "))
+	}
+}
@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// Cobra flags
+var (
+	reposOrg         string
+	reposAffiliation string
+	reposVisibility  string
+	reposType        string
+	reposOutputFile  string
+)
+
+// Init "repos" subcommand
+var reposCmd = &cobra.Command{
+	Use:   "repos",
+	Short: "Operations on your own & organization repositories",
+}
+
+// Init "repos get" subcommand
+var reposGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get repositories you own, collaborate on, or can access in an organization",
+	Long: `Get repositories from /user/repos, or an organization's repositories with --org.
+
+--type can't be combined with --affiliation or --visibility for your own
+repositories (a Github API restriction). Repositories saved to the database
+are tagged with why they're tracked: owned, collaborator, or org. Org
+repositories are only saved for organizations you're a member of, or for any
+organization when authenticated as a Github App.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// A filtered fetch would look like every other repository is gone
+		if pruneMissing && (reposType != "" || reposAffiliation != "" || reposVisibility != "") {
+			return fmt.Errorf("--prune-missing can't be combined with --type, --affiliation or --visibility")
+		}
+
+		endpoint, err := reposEndpoint()
+		if err != nil {
+			return err
+		}
+
+		// Create Github API client with HTTP cache
+		client, err := newCachedGithubClient()
+		if err != nil {
+			return err
+		}
+
+		// Saving checks org membership, which needs read:org
+		var scopes []string
+		if saveDB && viper.GetString("app_id") == "" {
+			scopes = append(scopes, "read:org")
+		}
+
+		// Validate token before paging through all repositories
+		if err := runPreflight(client, scopes...); err != nil {
+			return err
+		}
+
+		// Make HTTP requests to fetch repositories
+		allRepos, err := ghclient.FetchAllPages[Github.Repository](client, apiURL(endpoint), viper.GetInt("request_sleep"))
+		if err != nil {
+			return fmt.Errorf("error fetching repositories: %w", err)
+		}
+
+		if len(allRepos) == 0 {
+			return fmt.Errorf("no repositories returned")
+		}
+
+		fmt.Printf("Fetched %d repositories.\n", len(allRepos))
+
+		if saveDB {
+			// Group repositories by why they're tracked
+			byReason, err := groupReposByReason(client, allRepos)
+			if err != nil {
+				return err
+			}
+
+			// Initialize database
+			dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+			if err != nil {
+				return fmt.Errorf("error initializing database: %w", err)
+			}
+
+			// Save retrieved repositories
+			for _, reason := range []string{Github.TrackOwned, Github.TrackCollaborator, Github.TrackOrg} {
+				if len(byReason[reason]) == 0 {
+					continue
+				}
+				if err := db.SaveRepositories(dbConn, byReason[reason], reason); err != nil {
+					return fmt.Errorf("error saving repositories to database: %w", err)
+				}
+				fmt.Printf("Saved %d %s repositories to database.\n", len(byReason[reason]), reason)
+			}
+			if pruneMissing {
+				if err := untrackMissingOwnRepos(dbConn, byReason); err != nil {
+					return err
+				}
+			}
+		}
+
+		if saveJson {
+			// Write repositories to file
+			if err := writeJSONFile(reposOutputFile, allRepos); err != nil {
+				return err
+			}
+			fmt.Printf("Repositories saved to: %s\n", reposOutputFile)
+		}
+
+		return nil
+	},
+}
+
+// "repos" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(reposCmd)
+	reposCmd.AddCommand(reposGetCmd)
+
+	// Repository source & filters
+	reposGetCmd.Flags().StringVar(&reposOrg, "org", "", "Get an organization's repositories instead of your own")
+	reposGetCmd.Flags().StringVar(&reposAffiliation, "affiliation", "", "Comma-separated: owner, collaborator, organization_member (default: all)")
+	reposGetCmd.Flags().StringVar(&reposVisibility, "visibility", "", "all, public, or private (default: all)")
+	reposGetCmd.Flags().StringVar(&reposType, "type", "", "Your repos: all, owner, public, private, member. Org repos: all, public, private, forks, sources, member")
+
+	// Output
+	reposGetCmd.Flags().BoolVar(&saveJson, "save-json", false, "Save response content to a file")
+	reposGetCmd.Flags().StringVarP(&reposOutputFile, "output", "o", "repos.json", "Output file name")
+	reposGetCmd.Flags().BoolVar(&saveDB, "save-db", false, "Save response content to a database")
+	reposGetCmd.Flags().BoolVar(&pruneMissing, "prune-missing", false, "With --save-db, remove stored repositories missing from the fetch")
+}
+
+// Build the repositories endpoint with query parameters from flags
+func reposEndpoint() (string, error) {
+	query := url.Values{}
+	query.Set("per_page", "100")
+
+	if reposType != "" {
+		query.Set("type", reposType)
+	}
+
+	if reposOrg != "" {
+		if reposAffiliation != "" || reposVisibility != "" {
+			return "", fmt.Errorf("--affiliation & --visibility only apply to your own repositories, use --type with --org")
+		}
+		return fmt.Sprintf(constants.GH_ORG_REPOS_ENDPOINT, url.PathEscape(reposOrg)) + "?" + query.Encode(), nil
+	}
+
+	// Github App installation tokens have no user to list repositories for
+	if viper.GetString("app_id") != "" {
+		return "", fmt.Errorf("a Github App can only get organization repositories, use --org")
+	}
+
+	// Github rejects type combined with affiliation or visibility
+	if reposType != "" && (reposAffiliation != "" || reposVisibility != "") {
+		return "", fmt.Errorf("--type can't be combined with --affiliation or --visibility")
+	}
+	if reposAffiliation != "" {
+		query.Set("affiliation", reposAffiliation)
+	}
+	if reposVisibility != "" {
+		query.Set("visibility", reposVisibility)
+	}
+
+	return constants.GH_USER_REPOS_ENDPOINT + "?" + query.Encode(), nil
+}
+
+// Sort fetched repositories by tracking reason: owned, collaborator, or org
+func groupReposByReason(client *ghclient.Client, repos []Github.Repository) (map[string][]Github.Repository, error) {
+	byReason := map[string][]Github.Repository{}
+
+	// Org repos are only tracked for organizations the user belongs to.
+	// An App has no membership to check, its installation grants the org's repositories.
+	asApp := viper.GetString("app_id") != ""
+	members := map[string]bool{}
+	isMember := func(org string) (bool, error) {
+		if asApp {
+			return true, nil
+		}
+		key := strings.ToLower(org)
+		if member, ok := members[key]; ok {
+			return member, nil
+		}
+		member, err := client.IsOrgMember(org)
+		if err != nil {
+			return false, fmt.Errorf("error checking membership of %s: %w", org, err)
+		}
+		members[key] = member
+		return member, nil
+	}
+
+	// An org's repository list is only saved for members
+	if reposOrg != "" {
+		member, err := isMember(reposOrg)
+		if err != nil {
+			return nil, err
+		}
+		if !member {
+			fmt.Fprintf(os.Stderr, "Not a member of %s, its repositories aren't saved to the database (use --save-json instead).\n", reposOrg)
+			return byReason, nil
+		}
+		byReason[Github.TrackOrg] = repos
+		return byReason, nil
+	}
+
+	// Get the authenticated user to tell owned repositories apart
+	user, _, err := client.GetUser()
+	if err != nil {
+		return nil, fmt.Errorf("error getting authenticated user: %w", err)
+	}
+
+	for _, repo := range repos {
+		reason := Github.TrackCollaborator
+		switch {
+		case strings.EqualFold(repo.Owner.Login, user.Login):
+			reason = Github.TrackOwned
+		case repo.Owner.Type == "Organization":
+			// Outside collaborators see org repos too
+			member, err := isMember(repo.Owner.Login)
+			if err != nil {
+				return nil, err
+			}
+			if member {
+				reason = Github.TrackOrg
+			}
+		}
+		byReason[reason] = append(byReason[reason], repo)
+	}
+
+	return byReason, nil
+}
+
+// Stop tracking stored repositories missing from a full fetch, for each reason it covers.
+// An --org fetch only covers that organization, other organizations' repositories are kept.
+func untrackMissingOwnRepos(dbConn *gorm.DB, byReason map[string][]Github.Repository) error {
+	if reposOrg == "" {
+		for _, reason := range []string{Github.TrackOwned, Github.TrackCollaborator, Github.TrackOrg} {
+			if err := untrackMissingRepos(dbConn, byReason[reason], reason); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	stored, err := db.LoadRepositories(dbConn, Github.TrackOrg)
+	if err != nil {
+		return fmt.Errorf("error loading repositories from database: %w", err)
+	}
+
+	keep := slices.Clone(byReason[Github.TrackOrg])
+	for _, repo := range stored {
+		if repo.Owner == nil || !strings.EqualFold(repo.Owner.Login, reposOrg) {
+			keep = append(keep, Github.Repository{ID: repo.ID})
+		}
+	}
+
+	return untrackMissingRepos(dbConn, keep, Github.TrackOrg)
+}
@@ -1,19 +1,32 @@
 package cmd
 
 import (
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/config"
+	"github.com/redjax/go-mygithub/internal/constants"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 // Set global CLI args
 var (
-	accessToken string
+	accessToken   string
+	cfgFile       string
+	profile       string
+	requestSleep  int
+	cacheDir      string
+	cacheDuration int
 )
 
 // Initialize root CLI
 var rootCmd = &cobra.Command{
 	Use:   "mygithub",
 	Short: "CLI for Github",
+	// Load config file & profile before any subcommand runs
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return config.Load(viper.GetViper(), cfgFile)
+	},
 }
 
 // Function to start CLI
@@ -26,4 +39,43 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&accessToken, "access-token", "t", "", "GitHub Personal Access Token (PAT)")
 	viper.BindPFlag("access_token", rootCmd.PersistentFlags().Lookup("access-token"))
 	viper.BindEnv("access_token", "GITHUB_TOKEN", "GH_TOKEN")
+
+	// Authenticate as a Github App installation instead of with a PAT
+	rootCmd.PersistentFlags().String("app-id", "", "Github App ID (or client ID)")
+	rootCmd.PersistentFlags().String("app-private-key", "", "Path to the Github App private key PEM")
+	rootCmd.PersistentFlags().Int64("app-installation-id", 0, "Github App installation ID")
+	viper.BindPFlag("app_id", rootCmd.PersistentFlags().Lookup("app-id"))
+	viper.BindPFlag("app_private_key", rootCmd.PersistentFlags().Lookup("app-private-key"))
+	viper.BindPFlag("app_installation_id", rootCmd.PersistentFlags().Lookup("app-installation-id"))
+
+	// Time between requests, shared by all commands that page or batch requests
+	rootCmd.PersistentFlags().IntVar(&requestSleep, "request-sleep", 0, "Time between requests (seconds)")
+	viper.BindPFlag("request_sleep", rootCmd.PersistentFlags().Lookup("request-sleep"))
+
+	// HTTP cache control flags
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", ".httpcache", "Directory for HTTP cache storage")
+	rootCmd.PersistentFlags().IntVar(&cacheDuration, "cache-duration", 5, "HTTP cache duration in minutes (0 to disable)")
+	viper.BindPFlag("cache_dir", rootCmd.PersistentFlags().Lookup("cache-dir"))
+	viper.BindPFlag("cache_duration", rootCmd.PersistentFlags().Lookup("cache-duration"))
+	viper.SetDefault("cache_dir", ".httpcache")
+	viper.SetDefault("cache_duration", 5)
+
+	// Skip the token & rate limit check before API calls
+	rootCmd.PersistentFlags().Bool("skip-preflight", false, "Skip checking the token & rate limit before running")
+	viper.BindPFlag("skip_preflight", rootCmd.PersistentFlags().Lookup("skip-preflight"))
+
+	// Config file & profile selection
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "Config file (default ~/.config/mygithub/config.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "p", "", "Config profile to use")
+	viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile"))
+	viper.BindEnv("profile", "MYGITHUB_PROFILE")
+
+	// Settings that can be overridden per profile
+	viper.SetDefault("api_url", constants.GH_API_URL)
+	viper.SetDefault("db_dsn", constants.DEFAULT_DB_DSN)
+}
+
+// Build a full Github API URL from an endpoint path
+func apiURL(endpoint string) string {
+	return strings.TrimRight(viper.GetString("api_url"), "/") + endpoint
 }
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+)
+
+// Cobra flags
+var (
+	reposFile string
+	dryRun    bool
+)
+
+// Init "starred add" subcommand
+var starredAddCmd = &cobra.Command{
+	Use:   "add [owner/repo]...",
+	Short: "Star repositories",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return editStars(args, true)
+	},
+}
+
+// Init "starred remove" subcommand
+var starredRemoveCmd = &cobra.Command{
+	Use:     "remove [owner/repo]...",
+	Aliases: []string{"rm"},
+	Short:   "Unstar repositories",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return editStars(args, false)
+	},
+}
+
+// "starred add/remove" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredAddCmd)
+	starredCmd.AddCommand(starredRemoveCmd)
+
+	for _, c := range []*cobra.Command{starredAddCmd, starredRemoveCmd} {
+		// Batch input
+		c.Flags().StringVarP(&reposFile, "file", "f", "", "Read owner/repo names from a file, one per line (- for stdin)")
+		// Show changes without making them
+		c.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without starring or unstarring")
+	}
+}
+
+// Star or unstar repositories from args & --file, keeping the database in sync
+func editStars(args []string, star bool) error {
+	names, err := collectRepoNames(args, reposFile)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("no repositories given (pass owner/repo arguments or --file)")
+	}
+
+	action, verb := "unstar", "Unstarred"
+	if star {
+		action, verb = "star", "Starred"
+	}
+
+	if dryRun {
+		for _, name := range names {
+			fmt.Printf("[dry run] Would %s %s\n", action, name)
+		}
+		return nil
+	}
+
+	// Create Github API client without the HTTP cache, so DB sync sees fresh data
+	client, err := newGithubClient(nil)
+	if err != nil {
+		return err
+	}
+
+	// Starring requires the public_repo scope (repo covers it)
+	if err := runPreflight(client, "public_repo"); err != nil {
+		return err
+	}
+
+	// Initialize database
+	dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+	if err != nil {
+		return fmt.Errorf("error initializing database: %w", err)
+	}
+
+	failed := 0
+	for i, name := range names {
+		if i > 0 {
+			// Wait before next request
+			time.Sleep(time.Duration(viper.GetInt("request_sleep")) * time.Second)
+		}
+
+		if err := setStar(client, dbConn, name, star); err != nil {
+			// Unstarred on GitHub, there just wasn't anything to remove locally
+			if !errors.Is(err, db.ErrRepositoryNotFound) {
+				fmt.Fprintf(os.Stderr, "  %s: %v\n", name, err)
+				failed++
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "  Warning: %s: %v\n", name, err)
+		}
+
+		fmt.Printf("%s %s\n", verb, name)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed", failed, len(names))
+	}
+
+	return nil
+}
+
+// Star or unstar a single repository & update the database to match
+func setStar(client *ghclient.Client, dbConn *gorm.DB, name string, star bool) error {
+	if !star {
+		if err := client.UnstarRepo(name); err != nil {
+			return err
+		}
+		err := db.UntrackRepository(dbConn, name, Github.TrackStarred)
+		if errors.Is(err, db.ErrRepositoryNotFound) {
+			return fmt.Errorf("unstarred, but %w", err)
+		}
+		if err != nil {
+			return fmt.Errorf("unstarred, but error removing from database: %w", err)
+		}
+		return nil
+	}
+
+	if err := client.StarRepo(name); err != nil {
+		return err
+	}
+
+	// Fetch full repository details for the database
+	repo, err := client.GetRepo(name)
+	if err != nil {
+		return fmt.Errorf("starred, but error fetching details: %w", err)
+	}
+	starredAt := time.Now().UTC()
+	repo.StarredAt = &starredAt
+	if err := db.SaveRepositories(dbConn, []Github.Repository{*repo}, Github.TrackStarred); err != nil {
+		return fmt.Errorf("starred, but error saving to database: %w", err)
+	}
+
+	return nil
+}
+
+// Combine & normalize repository names from args & an optional file (- for stdin)
+func collectRepoNames(args []string, file string) ([]string, error) {
+	refs := append([]string{}, args...)
+
+	if file != "" {
+		var r io.Reader = os.Stdin
+		if file != "-" {
+			f, err := os.Open(file)
+			if err != nil {
+				return nil, fmt.Errorf("error opening %s: %w", file, err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		lines, err := readRepoLines(r)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, lines...)
+	}
+
+	// Normalize & de-duplicate
+	seen := map[string]bool{}
+	var names []string
+	for _, ref := range refs {
+		name, err := ghclient.ParseRepoName(ref)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// Read one repository per line, skipping blank lines & # comments
+func readRepoLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading repository list: %w", err)
+	}
+
+	return lines, nil
+}
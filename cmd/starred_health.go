@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/redjax/go-mygithub/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	healthFormat     string
+	healthDeadMonths int
+	healthDeadOnly   bool
+	healthTop        int
+)
+
+// Init "starred health" subcommand
+var starredHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Score the maintenance health of starred repositories",
+	Long: `Score each starred repository in the database from 0 to 100 on recent
+pushes, issues being enabled, & having a license, plus release recency &
+contributor count when "mygithub enrich" has fetched them. Repositories are
+listed least healthy first.
+
+Archived repositories & those not pushed to in --dead-months are flagged as
+likely dead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(healthFormat, output.FormatTable, output.FormatJSON, output.FormatCSV); err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		repos, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+		if len(repos) == 0 {
+			return fmt.Errorf("no repositories in database (run 'mygithub starred get --save-db' first)")
+		}
+		releases, err := db.LoadReleases(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading releases from database: %w", err)
+		}
+		contributors, err := db.LoadContributors(dbConn)
+		if err != nil {
+			return fmt.Errorf("error loading contributors from database: %w", err)
+		}
+
+		all := report.BuildHealth(repos, releases, contributors, healthDeadMonths, time.Now())
+
+		health := all
+		if healthDeadOnly {
+			health = []report.RepoHealth{}
+			for _, h := range all {
+				if h.LikelyDead {
+					health = append(health, h)
+				}
+			}
+		}
+		if healthTop > 0 && len(health) > healthTop {
+			health = health[:healthTop]
+		}
+
+		switch healthFormat {
+		case output.FormatJSON:
+			return output.WriteJSON(os.Stdout, health)
+		case output.FormatCSV:
+			return output.WriteCSV(os.Stdout, report.HealthCSVHeader, report.HealthCSVRows(health))
+		}
+
+		t := output.NewTable(os.Stdout)
+		fmt.Fprintln(t, "SCORE\tREPOSITORY\tLAST PUSH\tLAST RELEASE\tCONTRIBUTORS\tFLAGS")
+		for _, h := range health {
+			score := fmt.Sprintf("%d", h.Score)
+			if h.LikelyDead {
+				score += " dead?"
+			}
+			lastRelease, contributorCount := "-", "-"
+			if h.LastRelease != nil {
+				lastRelease = h.LastRelease.Local().Format("2006-01-02")
+			}
+			if h.Contributors != nil {
+				contributorCount = fmt.Sprintf("%d", *h.Contributors)
+			}
+			fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\n", score, h.FullName, h.PushedAt.Local().Format("2006-01-02"), lastRelease, contributorCount, strings.Join(h.Flags, ", "))
+		}
+		t.Flush()
+
+		dead := 0
+		for _, h := range all {
+			if h.LikelyDead {
+				dead++
+			}
+		}
+		fmt.Printf("\n%d repositories, %d likely dead.\n", len(all), dead)
+		if len(releases) == 0 && len(contributors) == 0 {
+			fmt.Println("Run 'mygithub enrich --only releases,contributors' to score release cadence & contributors too.")
+		}
+
+		return nil
+	},
+}
+
+// "starred health" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredHealthCmd)
+
+	starredHealthCmd.Flags().StringVar(&healthFormat, "format", output.FormatTable, "Output format: table, json, or csv")
+	starredHealthCmd.Flags().IntVar(&healthDeadMonths, "dead-months", 24, "Flag repositories not pushed to in this many months as likely dead")
+	starredHealthCmd.Flags().BoolVar(&healthDeadOnly, "dead", false, "Only show likely dead repositories")
+	starredHealthCmd.Flags().IntVar(&healthTop, "top", 0, "Show only the N least healthy repositories (0 for all)")
+}
@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	importFormat string
+)
+
+// Github asks for at least a second between requests that create content
+const minImportSleep = 1
+
+// Init "starred import" subcommand
+var starredImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Star repositories from a backup or list",
+	Long: `Star every repository in a file that isn't already starred on this account.
+
+Reads the JSON written by "starred get --save-json" or "starred prune",
+a CSV with a full_name (or owner & name) column, or a plain list with
+one owner/repo per line. Use "-" to read a plain list from stdin,
+together with --yes or --dry-run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The list uses up stdin, so there's nothing left to answer the prompt
+		if args[0] == "-" && !assumeYes && !dryRun {
+			return fmt.Errorf("reading from stdin can't be confirmed interactively (use --yes, or --dry-run to preview)")
+		}
+
+		// Read repository names from file
+		names, err := readImportFile(args[0], importFormat)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no repositories found in %s", args[0])
+		}
+
+		// Create Github API client without the HTTP cache, so current stars are accurate
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		// Starring requires the public_repo scope (repo covers it)
+		if err := runPreflight(client, "public_repo"); err != nil {
+			return err
+		}
+
+		// Fetch current stars to find what's missing
+		current, err := client.FetchStarredRepos(viper.GetInt("request_sleep"))
+		if err != nil {
+			return fmt.Errorf("error fetching starred repositories: %w", err)
+		}
+
+		starred := map[string]bool{}
+		for _, repo := range current {
+			starred[strings.ToLower(repo.FullName)] = true
+		}
+
+		var missing []string
+		for _, name := range names {
+			if !starred[strings.ToLower(name)] {
+				missing = append(missing, name)
+			}
+		}
+
+		// Preview changes
+		fmt.Printf("\n%d repositories in %s, %d already starred, %d to star:\n", len(names), args[0], len(names)-len(missing), len(missing))
+		for _, name := range missing {
+			fmt.Printf("  + %s\n", name)
+		}
+
+		if len(missing) == 0 || dryRun {
+			return nil
+		}
+
+		if !assumeYes && !confirm(fmt.Sprintf("Star %d repositories?", len(missing))) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		sleep := time.Duration(max(viper.GetInt("request_sleep"), minImportSleep)) * time.Second
+
+		failed := 0
+		for i, name := range missing {
+			if i > 0 {
+				// Wait before next request
+				time.Sleep(sleep)
+			}
+
+			err := setStar(client, dbConn, name, true)
+
+			// Wait out the rate limit & retry once
+			var apiErr *ghclient.APIError
+			if errors.Is(err, ghclient.ErrRateLimited) && errors.As(err, &apiErr) {
+				wait := time.Until(apiErr.RateLimitReset) + time.Second
+				fmt.Printf("  Rate limited, waiting %s...\n", wait.Round(time.Second))
+				time.Sleep(wait)
+				err = setStar(client, dbConn, name, true)
+			}
+
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "  %s: %v\n", name, err)
+				failed++
+				continue
+			}
+
+			fmt.Printf("Starred %s (%d/%d)\n", name, i+1, len(missing))
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d repositories failed", failed, len(missing))
+		}
+
+		return nil
+	},
+}
+
+// "starred import" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredImportCmd)
+
+	starredImportCmd.Flags().StringVar(&importFormat, "format", "", "Input format: json, csv, or list (default: from file extension)")
+	starredImportCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Star without asking for confirmation")
+	starredImportCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show repositories that would be starred")
+}
+
+// Read repository names from a JSON backup, CSV, or plain list
+func readImportFile(path string, format string) ([]string, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			format = "json"
+		case ".csv":
+			format = "csv"
+		default:
+			format = "list"
+		}
+	}
+
+	var refs []string
+	var err error
+
+	switch format {
+	case "json":
+		refs, err = readImportJSON(path)
+	case "csv":
+		refs, err = readImportCSV(path)
+	case "list":
+		// Plain lists share parsing with "starred add --file"
+		return collectRepoNames(nil, path)
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected json, csv, or list)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return collectRepoNames(refs, "")
+}
+
+// Read repository names from exported []Github.Repository JSON
+func readImportJSON(path string) ([]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	var repos []Github.Repository
+	if err := json.Unmarshal(raw, &repos); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	var refs []string
+	for _, repo := range repos {
+		name := repo.FullName
+		if name == "" && repo.Owner.Login != "" && repo.Name != "" {
+			name = repo.Owner.Login + "/" + repo.Name
+		}
+		if name != "" {
+			refs = append(refs, name)
+		}
+	}
+
+	return refs, nil
+}
+
+// Read repository names from a CSV with a full_name column, owner & name
+// columns, or owner/repo values in the first column
+func readImportCSV(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// Find columns from the header row
+	header := make([]string, len(records[0]))
+	for i, col := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(col))
+	}
+	fullNameCol := slices.IndexFunc(header, func(c string) bool {
+		return c == "full_name" || c == "repository" || c == "repo"
+	})
+	ownerCol := slices.Index(header, "owner")
+	nameCol := slices.Index(header, "name")
+
+	var refs []string
+	switch {
+	case fullNameCol >= 0:
+		for _, rec := range records[1:] {
+			if fullNameCol < len(rec) && rec[fullNameCol] != "" {
+				refs = append(refs, rec[fullNameCol])
+			}
+		}
+	case ownerCol >= 0 && nameCol >= 0:
+		for _, rec := range records[1:] {
+			if ownerCol < len(rec) && nameCol < len(rec) {
+				refs = append(refs, rec[ownerCol]+"/"+rec[nameCol])
+			}
+		}
+	default:
+		// No recognized header, use the first column of every row
+		for _, rec := range records {
+			if len(rec) > 0 && strings.TrimSpace(rec[0]) != "" {
+				refs = append(refs, rec[0])
+			}
+		}
+	}
+
+	return refs, nil
+}
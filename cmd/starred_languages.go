@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/enrich"
+	"github.com/spf13/cobra"
+)
+
+// Cobra flags
+var (
+	languagesForce bool
+	languagesLimit int
+)
+
+// Init "starred languages" subcommand
+var starredLanguagesCmd = &cobra.Command{
+	Use:   "languages",
+	Short: "Fetch per-language byte counts of starred repositories",
+	Long: `Fetch the languages of every starred repository in the database & store
+the bytes of code in each language, for "mygithub stats languages".
+Same as "mygithub enrich --only languages".
+
+Runs are incremental: a repository is only fetched again once it's pushed to.
+Use --limit to spread a large backlog over several runs. If the API rate limit
+runs out, the run stops & the next one picks up where it left off.
+
+Run "mygithub starred get --save-db" first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEnrichers(os.Stdout, []enrich.Enricher{enrich.Languages{}}, Github.TrackStarred, enrich.Options{
+			Workers: enrich.DefaultWorkers,
+			Force:   languagesForce,
+			Limit:   languagesLimit,
+			Reserve: enrich.DefaultReserve,
+		})
+	},
+}
+
+// "starred languages" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredLanguagesCmd)
+
+	starredLanguagesCmd.Flags().BoolVar(&languagesForce, "force", false, "Fetch every repository, even if unchanged since the last run")
+	starredLanguagesCmd.Flags().IntVar(&languagesLimit, "limit", 0, "Fetch at most N repositories this run (0 for all)")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	inList string
+)
+
+// Init "starred lists" subcommand
+var starredListsCmd = &cobra.Command{
+	Use:   "lists",
+	Short: "Fetch star lists & their repositories into the database",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create Github API client
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		// Validate token before querying the GraphQL API
+		if err := runPreflight(client); err != nil {
+			return err
+		}
+
+		// Fetch lists & memberships
+		lists, err := client.FetchStarLists()
+		if err != nil {
+			return fmt.Errorf("error fetching star lists: %w", err)
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		// Replace stored lists
+		if err := db.SaveStarLists(dbConn, lists); err != nil {
+			return fmt.Errorf("error saving star lists to database: %w", err)
+		}
+
+		t := output.NewTable(os.Stdout)
+		fmt.Fprintln(t, "LIST\tREPOS\tVISIBILITY")
+		for _, list := range lists {
+			visibility := "public"
+			if list.IsPrivate {
+				visibility = "private"
+			}
+			fmt.Fprintf(t, "%s\t%d\t%s\n", list.Name, len(list.Items), visibility)
+		}
+		t.Flush()
+
+		fmt.Printf("\nSaved %d star lists to database.\n", len(lists))
+
+		return nil
+	},
+}
+
+// Init "starred list" subcommand
+var starredListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List starred repositories stored in the database",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		// Load stored repositories, optionally filtered by star list
+		var repos []Github.RepositoryModel
+		if inList != "" {
+			repos, err = db.LoadRepositoriesInList(dbConn, inList)
+		} else {
+			repos, err = db.LoadRepositories(dbConn, Github.TrackStarred)
+		}
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+
+		printRepoTable(repos)
+		fmt.Printf("\n%d repositories.\n", len(repos))
+
+		return nil
+	},
+}
+
+// "starred lists/list" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredListsCmd)
+	starredCmd.AddCommand(starredListCmd)
+
+	// Filter by star list name or slug
+	starredListCmd.Flags().StringVar(&inList, "in-list", "", "Only show repositories in this star list (run 'starred lists' first)")
+}
+
+// Print repositories as a table
+func printRepoTable(repos []Github.RepositoryModel) {
+	t := output.NewTable(os.Stdout)
+	fmt.Fprintln(t, "REPOSITORY\tLANGUAGE\tSTARS\tDESCRIPTION")
+
+	for _, repo := range repos {
+		language := "-"
+		if repo.Language.Valid {
+			language = repo.Language.String
+		}
+		fmt.Fprintf(t, "%s\t%s\t%d\t%s\n", repo.FullName, language, repo.StargazersCount, truncate(repo.Description.String, 60))
+	}
+
+	t.Flush()
+}
+
+// Shorten a string to at most n runes, adding an ellipsis if cut
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n-1]) + "…"
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	pruneArchived    bool
+	pruneDisabled    bool
+	pruneStaleMonths int
+	pruneLanguages   []string
+	pruneMinStars    int
+	pruneBackupFile  string
+	assumeYes        bool
+)
+
+// A starred repository matched by one or more prune rules
+type pruneCandidate struct {
+	Repo    Github.RepositoryModel
+	Reasons []string
+}
+
+// Init "starred prune" subcommand
+var starredPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Unstar stored repositories that match cleanup rules",
+	Long: `Unstar repositories in the database that match any of the given rules.
+
+Run "mygithub starred get --save-db" first so the rules see current data.
+Candidates are shown for confirmation, and a JSON backup of them is written
+before anything is unstarred. Restore it with "mygithub starred import".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !pruneArchived && !pruneDisabled && pruneStaleMonths <= 0 && len(pruneLanguages) == 0 && pruneMinStars <= 0 {
+			return fmt.Errorf("no prune rules given (use --archived, --disabled, --stale-months, --language, or --min-stars)")
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		// Load stored repositories
+		repos, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+		if len(repos) == 0 {
+			return fmt.Errorf("no repositories in database (run 'mygithub starred get --save-db' first)")
+		}
+
+		// Evaluate rules
+		candidates := findPruneCandidates(repos, time.Now())
+		if len(candidates) == 0 {
+			fmt.Printf("No repositories matched out of %d stored.\n", len(repos))
+			return nil
+		}
+
+		printPruneCandidates(candidates)
+		fmt.Printf("\n%d of %d stored repositories matched.\n", len(candidates), len(repos))
+
+		if dryRun {
+			return nil
+		}
+
+		// Create Github API client
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		// Unstarring requires the public_repo scope (repo covers it)
+		if err := runPreflight(client, "public_repo"); err != nil {
+			return err
+		}
+
+		if !assumeYes && !confirm(fmt.Sprintf("Unstar %d repositories?", len(candidates))) {
+			fmt.Println("Aborted.")
+			return nil
+		}
+
+		// Back up candidates before unstarring anything
+		backup := make([]Github.Repository, len(candidates))
+		for i, c := range candidates {
+			backup[i] = db.ConvertModelToRepository(c.Repo)
+		}
+		if err := writeJSONFile(pruneBackupFile, backup); err != nil {
+			return fmt.Errorf("error writing backup, nothing was unstarred: %w", err)
+		}
+		fmt.Printf("Backup of pruned repositories saved to: %s\n", pruneBackupFile)
+
+		failed := 0
+		for i, c := range candidates {
+			if i > 0 {
+				// Wait before next request
+				time.Sleep(time.Duration(viper.GetInt("request_sleep")) * time.Second)
+			}
+
+			if err := setStar(client, dbConn, c.Repo.FullName, false); err != nil {
+				fmt.Fprintf(os.Stderr, "  %s: %v\n", c.Repo.FullName, err)
+				failed++
+				continue
+			}
+
+			fmt.Printf("Unstarred %s\n", c.Repo.FullName)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d repositories failed", failed, len(candidates))
+		}
+
+		return nil
+	},
+}
+
+// "starred prune" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredPruneCmd)
+
+	// Prune rules, a repository matching any rule is a candidate
+	starredPruneCmd.Flags().BoolVar(&pruneArchived, "archived", false, "Match archived repositories")
+	starredPruneCmd.Flags().BoolVar(&pruneDisabled, "disabled", false, "Match disabled repositories")
+	starredPruneCmd.Flags().IntVar(&pruneStaleMonths, "stale-months", 0, "Match repositories not pushed to in this many months")
+	starredPruneCmd.Flags().StringSliceVar(&pruneLanguages, "language", nil, "Match repositories with these primary languages (comma-separated)")
+	starredPruneCmd.Flags().IntVar(&pruneMinStars, "min-stars", 0, "Match repositories with fewer stargazers than this")
+
+	// Backup & confirmation
+	starredPruneCmd.Flags().StringVar(&pruneBackupFile, "backup", fmt.Sprintf("pruned_stars_%s.json", time.Now().Format("20060102-150405")), "File to back up pruned repositories to")
+	starredPruneCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Unstar without asking for confirmation")
+	starredPruneCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show matching repositories")
+}
+
+// Evaluate prune rules against stored repositories
+func findPruneCandidates(repos []Github.RepositoryModel, now time.Time) []pruneCandidate {
+	staleBefore := now.AddDate(0, -pruneStaleMonths, 0)
+
+	var candidates []pruneCandidate
+	for _, repo := range repos {
+		var reasons []string
+
+		if pruneArchived && repo.Archived {
+			reasons = append(reasons, "archived")
+		}
+		if pruneDisabled && repo.Disabled {
+			reasons = append(reasons, "disabled")
+		}
+		if pruneStaleMonths > 0 && repo.PushedAt.Before(staleBefore) {
+			reasons = append(reasons, fmt.Sprintf("no push since %s", repo.PushedAt.Format("2006-01")))
+		}
+		if len(pruneLanguages) > 0 && repo.Language.Valid && slices.ContainsFunc(pruneLanguages, func(l string) bool {
+			return strings.EqualFold(l, repo.Language.String)
+		}) {
+			reasons = append(reasons, "language "+repo.Language.String)
+		}
+		if pruneMinStars > 0 && repo.StargazersCount < pruneMinStars {
+			reasons = append(reasons, fmt.Sprintf("%d stars", repo.StargazersCount))
+		}
+
+		if len(reasons) > 0 {
+			candidates = append(candidates, pruneCandidate{Repo: repo, Reasons: reasons})
+		}
+	}
+
+	return candidates
+}
+
+// Print prune candidates as a table
+func printPruneCandidates(candidates []pruneCandidate) {
+	t := output.NewTable(os.Stdout)
+	fmt.Fprintln(t, "REPOSITORY\tLANGUAGE\tSTARS\tPUSHED\tREASONS")
+
+	for _, c := range candidates {
+		language := "-"
+		if c.Repo.Language.Valid {
+			language = c.Repo.Language.String
+		}
+		fmt.Fprintf(t, "%s\t%s\t%d\t%s\t%s\n", c.Repo.FullName, language, c.Repo.StargazersCount, c.Repo.PushedAt.Format("2006-01-02"), strings.Join(c.Reasons, ", "))
+	}
+
+	t.Flush()
+}
+
+// Ask a yes/no question on stdin, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/enrich"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	readmesDir   string
+	readmesForce bool
+	readmesLimit int
+)
+
+// Init "starred readmes" subcommand
+var starredReadmesCmd = &cobra.Command{
+	Use:   "readmes",
+	Short: "Fetch READMEs of starred repositories for offline reading",
+	Long: `Fetch the README of every starred repository in the database & store it
+gzip-compressed, in the database or, with --dir, as <dir>/<owner>/<repo>.md.gz.
+Same as "mygithub enrich --only readme".
+
+Runs are incremental: a README is only fetched again once its repository is
+pushed to. Use --force after switching between the database & --dir.
+Read stored READMEs with "mygithub starred readme <owner/repo>".
+
+Run "mygithub starred get --save-db" first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEnrichers(os.Stdout, []enrich.Enricher{&enrich.Readme{Dir: readmesDir}}, Github.TrackStarred, enrich.Options{
+			Workers: enrich.DefaultWorkers,
+			Force:   readmesForce,
+			Limit:   readmesLimit,
+			Reserve: enrich.DefaultReserve,
+		})
+	},
+}
+
+// Init "starred readme" subcommand
+var starredReadmeCmd = &cobra.Command{
+	Use:   "readme <owner/repo>",
+	Short: "Print a stored README",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var content string
+		if readmesDir != "" {
+			var err error
+			content, err = enrich.ReadReadmeFile(readmesDir, args[0])
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("no README stored for %s in %s", args[0], readmesDir)
+			}
+			if err != nil {
+				return err
+			}
+		} else {
+			// Initialize database
+			dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+			if err != nil {
+				return fmt.Errorf("error initializing database: %w", err)
+			}
+
+			content, err = db.LoadReadme(dbConn, args[0])
+			if errors.Is(err, db.ErrRepositoryNotFound) {
+				return fmt.Errorf("%s isn't in the database (run 'mygithub starred get --save-db' first)", args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("error loading README from database: %w", err)
+			}
+			if content == "" {
+				return fmt.Errorf("no README stored for %s (run 'mygithub starred readmes' first)", args[0])
+			}
+		}
+
+		fmt.Print(content)
+		if !strings.HasSuffix(content, "\n") {
+			fmt.Println()
+		}
+
+		return nil
+	},
+}
+
+// "starred readmes" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredReadmesCmd)
+	starredCmd.AddCommand(starredReadmeCmd)
+
+	starredReadmesCmd.Flags().StringVar(&readmesDir, "dir", "", "Store READMEs in a directory instead of the database")
+	starredReadmesCmd.Flags().BoolVar(&readmesForce, "force", false, "Fetch every README, even if unchanged since the last run")
+	starredReadmesCmd.Flags().IntVar(&readmesLimit, "limit", 0, "Fetch at most N repositories this run (0 for all)")
+
+	starredReadmeCmd.Flags().StringVar(&readmesDir, "dir", "", "Read the README from a directory instead of the database")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/enrich"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/redjax/go-mygithub/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	releasesFormat     string
+	releasesOutputFile string
+	releasesSinceDays  int
+	releasesNoFetch    bool
+)
+
+// Sync state name for the last "starred releases" run
+const releasesSyncName = "starred_releases"
+
+// Digest window for the first run, when there's no last run
+const defaultReleasesSinceDays = 7
+
+// Init "starred releases" subcommand
+var starredReleasesCmd = &cobra.Command{
+	Use:   "releases",
+	Short: "Fetch recent releases of starred repositories & show what's new",
+	Long: `Fetch the recent releases of every starred repository in the database,
+store them, and write a digest of releases published since the last run
+(or the last 7 days on the first run, or --since-days).
+
+Run "mygithub starred get --save-db" first. Within the HTTP cache duration,
+unchanged releases are revalidated rather than downloaded again.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(releasesFormat, output.FormatMD, output.FormatJSON); err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		startedAt := time.Now()
+
+		// Digest starts at the last run, unless overridden
+		since, err := db.GetLastRun(dbConn, releasesSyncName)
+		if err != nil {
+			return fmt.Errorf("error loading last run: %w", err)
+		}
+		if releasesSinceDays > 0 {
+			since = startedAt.AddDate(0, 0, -releasesSinceDays)
+		} else if since.IsZero() {
+			since = startedAt.AddDate(0, 0, -defaultReleasesSinceDays)
+		}
+
+		if !releasesNoFetch {
+			if err := fetchStarredReleases(); err != nil {
+				return err
+			}
+		}
+
+		// Build digest from stored releases
+		releases, repoNames, err := db.LoadReleasesSince(dbConn, since)
+		if err != nil {
+			return fmt.Errorf("error loading releases from database: %w", err)
+		}
+		digest := report.BuildReleaseDigest(releases, repoNames, since, startedAt)
+
+		w, err := output.Create(releasesOutputFile)
+		if err != nil {
+			return err
+		}
+		defer w.Close()
+
+		if releasesFormat == output.FormatJSON {
+			err = output.WriteJSON(w, digest)
+		} else {
+			err = digest.WriteMarkdown(w)
+		}
+		if err != nil {
+			return err
+		}
+
+		if releasesOutputFile != "" && releasesOutputFile != "-" {
+			fmt.Printf("Digest of %d releases saved to: %s\n", len(digest.Releases), releasesOutputFile)
+		}
+
+		// The next digest starts here, unless this run used a fixed window
+		if releasesSinceDays == 0 {
+			if err := db.SetLastRun(dbConn, releasesSyncName, startedAt); err != nil {
+				return fmt.Errorf("error saving last run: %w", err)
+			}
+		}
+
+		return nil
+	},
+}
+
+// "starred releases" CLI entrypoint
+func init() {
+	starredCmd.AddCommand(starredReleasesCmd)
+
+	starredReleasesCmd.Flags().StringVar(&releasesFormat, "format", output.FormatMD, "Digest format: md or json")
+	starredReleasesCmd.Flags().StringVarP(&releasesOutputFile, "output", "o", "", "Write the digest to a file (default: stdout)")
+	starredReleasesCmd.Flags().IntVar(&releasesSinceDays, "since-days", 0, "Show releases from the last N days instead of since the last run")
+	starredReleasesCmd.Flags().BoolVar(&releasesNoFetch, "no-fetch", false, "Only build the digest from stored releases")
+}
+
+// Fetch & store the recent releases of every stored starred repository.
+// Repositories that fail are only warned about, so the digest is still written.
+func fetchStarredReleases() error {
+	// Check every repository each run, unchanged releases are revalidated through the HTTP cache
+	results, err := enrichRepositories(os.Stderr, []enrich.Enricher{enrich.Releases{}}, Github.TrackStarred, enrich.Options{
+		Workers: enrich.DefaultWorkers,
+		Force:   true,
+		Reserve: enrich.DefaultReserve,
+	})
+	if err != nil {
+		return err
+	}
+
+	if failed := failedRepositories(results); failed > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: releases of %d repositories couldn't be fetched, the digest may be missing some\n", failed)
+	}
+
+	return nil
+}
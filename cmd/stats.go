@@ -0,0 +1,347 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/chart"
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/redjax/go-mygithub/internal/report"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// Cobra flags
+var (
+	statsFormat string
+	statsSort   string
+	statsTop    int
+
+	summaryFormat string
+	summaryTop    int
+
+	chartsWidth int
+	chartsASCII bool
+	chartsTop   int
+)
+
+// Init "stats" subcommand
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Statistics about stored repositories",
+}
+
+// Init "stats languages" subcommand
+var statsLanguagesCmd = &cobra.Command{
+	Use:   "languages",
+	Short: "Languages across starred repositories, by repository count & bytes of code",
+	Long: `Aggregate languages across the starred repositories in the database.
+
+Byte counts come from "mygithub starred languages". Repositories it hasn't
+fetched yet only count toward their primary language.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(statsFormat, output.FormatTable, output.FormatJSON, output.FormatCSV); err != nil {
+			return err
+		}
+		if statsSort != report.SortByCount && statsSort != report.SortByBytes {
+			return fmt.Errorf("unknown sort %q (expected %s, %s)", statsSort, report.SortByCount, report.SortByBytes)
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		repos, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+		languages, err := db.LoadLanguages(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading languages from database: %w", err)
+		}
+
+		stats := report.BuildLanguageStats(repos, languages, statsSort)
+		if statsTop > 0 && len(stats.Languages) > statsTop {
+			stats.Languages = stats.Languages[:statsTop]
+		}
+
+		switch statsFormat {
+		case output.FormatJSON:
+			return output.WriteJSON(os.Stdout, stats)
+		case output.FormatCSV:
+			return output.WriteCSV(os.Stdout, report.LanguageStatsCSVHeader, stats.CSVRows())
+		}
+
+		t := output.NewTable(os.Stdout)
+		fmt.Fprintln(t, "LANGUAGE\tREPOS\tPRIMARY\tBYTES\tSHARE")
+		for _, l := range stats.Languages {
+			fmt.Fprintf(t, "%s\t%d\t%d\t%s\t%.1f%%\n", l.Language, l.Repositories, l.Primary, report.FormatBytes(l.Bytes), l.BytesShare)
+		}
+		t.Flush()
+
+		fmt.Printf("\n%d repositories, %d with language bytes (%s).\n", stats.Repositories, stats.Enriched, report.FormatBytes(stats.TotalBytes))
+		if stats.Enriched < stats.Repositories {
+			fmt.Println("Run 'mygithub starred languages' to fetch the rest.")
+		}
+
+		return nil
+	},
+}
+
+// Init "stats summary" subcommand
+var statsSummaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Aggregates over starred repositories in the database",
+	Long: `Summarize the starred repositories in the database: counts per language,
+license, owner, topic, year starred & year created, archived vs. active, &
+repository size.
+
+Tables show the top --top entries of each list, JSON includes every entry.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(summaryFormat, output.FormatTable, output.FormatJSON); err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		repos, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+		if len(repos) == 0 {
+			return fmt.Errorf("no repositories in database (run 'mygithub starred get --save-db' first)")
+		}
+
+		summary := report.BuildStarSummary(repos, time.Now())
+
+		if summaryFormat == output.FormatJSON {
+			return output.WriteJSON(os.Stdout, summary)
+		}
+
+		printStarSummary(os.Stdout, summary, summaryTop)
+
+		return nil
+	},
+}
+
+// Init "stats charts" subcommand
+var statsChartsCmd = &cobra.Command{
+	Use:   "charts",
+	Short: "Charts of starred repositories in the terminal",
+	Long: `Draw charts of the starred repositories in the database: sparklines of stars
+per month by star date & by creation date, & bar charts per year & of the top
+languages & topics.
+
+Charts fit the terminal width, or --width when output isn't a terminal, & use
+no color. Use --ascii where Unicode block characters don't render, i.e. some CI
+logs (the default when TERM is "dumb").`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		repos, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+		if len(repos) == 0 {
+			return fmt.Errorf("no repositories in database (run 'mygithub starred get --save-db' first)")
+		}
+
+		opts := chart.Options{Width: chartsWidth, ASCII: chartsASCII || os.Getenv("TERM") == "dumb"}
+		if opts.Width <= 0 {
+			opts.Width = terminalWidth()
+		}
+
+		printStarCharts(os.Stdout, repos, chartsTop, opts)
+
+		return nil
+	},
+}
+
+// "stats" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.AddCommand(statsLanguagesCmd)
+	statsCmd.AddCommand(statsSummaryCmd)
+	statsCmd.AddCommand(statsChartsCmd)
+
+	statsLanguagesCmd.Flags().StringVar(&statsFormat, "format", output.FormatTable, "Output format: table, json, or csv")
+	statsLanguagesCmd.Flags().StringVar(&statsSort, "sort", report.SortByCount, "Sort by repository count or bytes of code: count or bytes")
+	statsLanguagesCmd.Flags().IntVar(&statsTop, "top", 0, "Show only the top N languages (0 for all)")
+
+	statsSummaryCmd.Flags().StringVar(&summaryFormat, "format", output.FormatTable, "Output format: table or json")
+	statsSummaryCmd.Flags().IntVar(&summaryTop, "top", 10, "Show only the top N languages, licenses, owners & topics (0 for all)")
+
+	statsChartsCmd.Flags().IntVar(&chartsWidth, "width", 0, "Chart width in columns (default: terminal width, or $COLUMNS, or 80)")
+	statsChartsCmd.Flags().BoolVar(&chartsASCII, "ascii", false, "Draw with plain ASCII instead of Unicode blocks")
+	statsChartsCmd.Flags().IntVar(&chartsTop, "top", 10, "Chart only the top N languages & topics (0 for all)")
+}
+
+// Print a starred repository summary as tables, limiting ranked lists to top entries
+func printStarSummary(w io.Writer, s report.StarSummary, top int) {
+	pct := func(n int) string {
+		return fmt.Sprintf("%d (%.0f%%)", n, float64(n)*100/float64(s.Total))
+	}
+
+	fmt.Fprintf(w, "Starred repositories: %d\n\n", s.Total)
+
+	t := output.NewTable(w)
+	fmt.Fprintf(t, "Active\t%s\n", pct(s.Active))
+	fmt.Fprintf(t, "Archived\t%s\n", pct(s.Archived))
+	t.Flush()
+
+	// Ranked lists, most stars first
+	for _, section := range []struct {
+		title  string
+		counts map[string]int
+	}{
+		{"Languages", s.Languages},
+		{"Licenses", s.Licenses},
+		{"Owners", s.Owners},
+		{"Topics", s.Topics},
+	} {
+		keys := report.SortedCounts(section.counts)
+		fmt.Fprintf(w, "\n%s (%d):\n", section.title, len(keys))
+		if top > 0 && len(keys) > top {
+			keys = keys[:top]
+		}
+		t = output.NewTable(w)
+		for _, key := range keys {
+			fmt.Fprintf(t, "  %s\t%s\n", key, pct(section.counts[key]))
+		}
+		t.Flush()
+	}
+
+	// By year, oldest first
+	for _, section := range []struct {
+		title  string
+		counts map[string]int
+	}{
+		{"Starred per year", s.StarredByYear},
+		{"Created per year", s.CreatedByYear},
+	} {
+		fmt.Fprintf(w, "\n%s:\n", section.title)
+		t = output.NewTable(w)
+		for _, year := range report.SortedYears(section.counts) {
+			fmt.Fprintf(t, "  %s\t%d\n", year, section.counts[year])
+		}
+		t.Flush()
+	}
+
+	fmt.Fprintln(w, "\nSize:")
+	t = output.NewTable(w)
+	for _, bucket := range report.SizeBuckets {
+		fmt.Fprintf(t, "  %s\t%s\n", bucket, pct(s.Sizes[bucket]))
+	}
+	t.Flush()
+}
+
+// Width of the terminal on stdout, falling back to $COLUMNS & then the default chart width
+func terminalWidth() int {
+	if fd := int(os.Stdout.Fd()); term.IsTerminal(fd) {
+		if width, _, err := term.GetSize(fd); err == nil && width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+
+	return chart.DefaultWidth
+}
+
+// Print charts of starred repositories, limiting language & topic charts to top entries
+func printStarCharts(w io.Writer, repos []Github.RepositoryModel, top int, opts chart.Options) {
+	summary := report.BuildStarSummary(repos, time.Now())
+
+	// Sparklines are indented like bar charts
+	sparkOpts := opts
+	sparkOpts.Width = opts.Width - 2
+
+	var starred, created []time.Time
+	for _, repo := range repos {
+		if repo.StarredAt.Valid {
+			starred = append(starred, repo.StarredAt.Time.UTC())
+		}
+		if !repo.CreatedAt.IsZero() {
+			created = append(created, repo.CreatedAt.UTC())
+		}
+	}
+
+	for _, series := range []struct {
+		title string
+		times []time.Time
+	}{
+		{"Stars per month, by star date", starred},
+		{"Stars per month, by repository creation date", created},
+	} {
+		first, counts := report.MonthlyCounts(series.times)
+		if len(counts) == 0 {
+			continue
+		}
+		line, perColumn := chart.Sparkline(counts, sparkOpts)
+
+		last := first.AddDate(0, len(counts)-1, 0)
+		fmt.Fprintf(w, "%s, %s to %s", series.title, first.Format("2006-01"), last.Format("2006-01"))
+		if perColumn > 1 {
+			fmt.Fprintf(w, " (%d months per column)", perColumn)
+		}
+		fmt.Fprintf(w, ":\n  %s\n\n", line)
+	}
+
+	// Per year, oldest first
+	for _, section := range []struct {
+		title  string
+		counts map[string]int
+	}{
+		{"Stars per year starred", summary.StarredByYear},
+		{"Stars per year created", summary.CreatedByYear},
+	} {
+		bars := []chart.Bar{}
+		for _, year := range report.SortedYears(section.counts) {
+			bars = append(bars, chart.Bar{Label: year, Value: section.counts[year]})
+		}
+		fmt.Fprintf(w, "%s:\n", section.title)
+		chart.Bars(w, bars, opts)
+		fmt.Fprintln(w)
+	}
+
+	// Ranked, most stars first
+	for _, section := range []struct {
+		title  string
+		counts map[string]int
+	}{
+		{"Top languages", summary.Languages},
+		{"Top topics", summary.Topics},
+	} {
+		keys := report.SortedCounts(section.counts)
+		if len(keys) == 0 {
+			continue
+		}
+		if top > 0 && len(keys) > top {
+			keys = keys[:top]
+		}
+		bars := []chart.Bar{}
+		for _, key := range keys {
+			bars = append(bars, chart.Bar{Label: key, Value: section.counts[key]})
+		}
+		fmt.Fprintf(w, "%s:\n", section.title)
+		chart.Bars(w, bars, opts)
+		fmt.Fprintln(w)
+	}
+}
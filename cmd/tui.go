@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/redjax/go-mygithub/internal/tui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	tuiReadOnly bool
+)
+
+// Init "tui" subcommand
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse starred repositories in a terminal UI",
+	Long: `Browse the starred repositories in the database in a full-screen terminal UI:
+a list with fuzzy search & sorting, & a detail pane for the selected repository.
+
+Keys:
+  up/down, j/k      Move (pgup/pgdown, home/end to jump)
+  /                 Search name, language, topics & description (enter to keep, esc to clear)
+  s                 Cycle sort: starred date, stars, last push, name
+  r                 Reverse the sort
+  o, enter          Open the repository in the browser
+  u                 Unstar the repository (asks to confirm)
+  q, ctrl+c         Quit
+
+Browsing works offline. Only unstarring calls the API.
+Run "mygithub starred get --save-db" first.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		models, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+		if len(models) == 0 {
+			return fmt.Errorf("no repositories in database (run 'mygithub starred get --save-db' first)")
+		}
+
+		repos := make([]Github.Repository, len(models))
+		for i, model := range models {
+			repos[i] = db.ConvertModelToRepository(model)
+		}
+
+		opts := tui.Options{}
+		if !tuiReadOnly {
+			// Create the Github API client on first unstar, so browsing needs no token
+			var (
+				mu     sync.Mutex
+				client *ghclient.Client
+			)
+			opts.Unstar = func(fullName string) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if client == nil {
+					// Without the HTTP cache, so DB sync sees fresh data
+					c, err := newGithubClient(nil)
+					if err != nil {
+						return err
+					}
+					client = c
+				}
+
+				return setStar(client, dbConn, fullName, false)
+			}
+		}
+
+		return tui.Run(repos, opts)
+	},
+}
+
+// "tui" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+
+	tuiCmd.Flags().BoolVar(&tuiReadOnly, "read-only", false, "Disable unstarring")
+}
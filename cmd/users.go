@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	usersOutputFile string
+)
+
+// Init "users" subcommand
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Operations on followers & following",
+}
+
+// Init "users followers" subcommand
+var usersFollowersCmd = &cobra.Command{
+	Use:   "followers [login]",
+	Short: "Get a user's followers (default: you)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getFollows(args, Github.Followers)
+	},
+}
+
+// Init "users following" subcommand
+var usersFollowingCmd = &cobra.Command{
+	Use:   "following [login]",
+	Short: "Get the users a user follows (default: you)",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return getFollows(args, Github.Following)
+	},
+}
+
+// Init "users diff" subcommand
+var usersDiffCmd = &cobra.Command{
+	Use:   "diff [login]",
+	Short: "Show who followed or unfollowed since the last sync",
+	Long: `Compare a user's current followers & following with the ones stored by
+the last "users followers --save-db" / "users following --save-db" (or
+"users diff --save-db"). Pass --save-db to store the current lists, so the
+next diff starts from now.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create Github API client without the HTTP cache, so the diff is current
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		if err := runPreflight(client); err != nil {
+			return err
+		}
+
+		login, err := followsLogin(client, args)
+		if err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		for _, direction := range []string{Github.Followers, Github.Following} {
+			stored, err := db.LoadFollows(dbConn, login, direction)
+			if err != nil {
+				return fmt.Errorf("error loading %s from database: %w", direction, err)
+			}
+
+			current, err := fetchFollows(client, args, login, direction)
+			if err != nil {
+				return fmt.Errorf("error fetching %s: %w", direction, err)
+			}
+
+			if len(stored) == 0 {
+				fmt.Printf("\n%s: no previous sync, %d now\n", capitalize(direction), len(current))
+			} else {
+				added, removed := diffFollows(stored, current)
+				fmt.Printf("\n%s since %s: %d now, +%d -%d\n", capitalize(direction), stored[0].SyncedAt.Local().Format("2006-01-02 15:04"), len(current), len(added), len(removed))
+				for _, l := range added {
+					fmt.Printf("  + %s\n", l)
+				}
+				for _, l := range removed {
+					fmt.Printf("  - %s\n", l)
+				}
+			}
+
+			if saveDB {
+				if err := db.SaveFollows(dbConn, login, direction, current); err != nil {
+					return fmt.Errorf("error saving %s to database: %w", direction, err)
+				}
+			}
+		}
+
+		if saveDB {
+			fmt.Println("\nSaved current followers & following to database.")
+		}
+
+		return nil
+	},
+}
+
+// "users" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(usersCmd)
+	usersCmd.AddCommand(usersFollowersCmd)
+	usersCmd.AddCommand(usersFollowingCmd)
+	usersCmd.AddCommand(usersDiffCmd)
+
+	for _, c := range []*cobra.Command{usersFollowersCmd, usersFollowingCmd} {
+		c.Flags().BoolVar(&saveJson, "save-json", false, "Save response content to a file")
+		c.Flags().StringVarP(&usersOutputFile, "output", "o", "", "Output file name (default: <login>_<followers|following>.json)")
+		c.Flags().BoolVar(&saveDB, "save-db", false, "Save to the database, replacing the last sync")
+	}
+	usersDiffCmd.Flags().BoolVar(&saveDB, "save-db", false, "Save current followers & following, replacing the last sync")
+}
+
+// Fetch a user's followers or following, print them & optionally save
+func getFollows(args []string, direction string) error {
+	// Create Github API client with HTTP cache
+	client, err := newCachedGithubClient()
+	if err != nil {
+		return err
+	}
+
+	if err := runPreflight(client); err != nil {
+		return err
+	}
+
+	login, err := followsLogin(client, args)
+	if err != nil {
+		return err
+	}
+
+	users, err := fetchFollows(client, args, login, direction)
+	if err != nil {
+		return fmt.Errorf("error fetching %s: %w", direction, err)
+	}
+
+	t := output.NewTable(os.Stdout)
+	fmt.Fprintln(t, "LOGIN\tTYPE\tPROFILE")
+	for _, user := range users {
+		fmt.Fprintf(t, "%s\t%s\t%s\n", user.Login, user.Type, user.HTMLURL)
+	}
+	t.Flush()
+	fmt.Printf("\n%s: %d %s.\n", login, len(users), direction)
+
+	if saveDB {
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		if err := db.SaveFollows(dbConn, login, direction, users); err != nil {
+			return fmt.Errorf("error saving %s to database: %w", direction, err)
+		}
+		fmt.Printf("Saved %s to database.\n", direction)
+	}
+
+	if saveJson {
+		path := usersOutputFile
+		if path == "" {
+			path = fmt.Sprintf("%s_%s.json", login, direction)
+		}
+		if err := writeJSONFile(path, users); err != nil {
+			return err
+		}
+		fmt.Printf("%s saved to: %s\n", capitalize(direction), path)
+	}
+
+	return nil
+}
+
+// Login from args, or the authenticated user's login
+func followsLogin(client *ghclient.Client, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	user, _, err := client.GetUser()
+	if err != nil {
+		return "", fmt.Errorf("error getting authenticated user: %w", err)
+	}
+
+	return user.Login, nil
+}
+
+// Fetch all followers or following, from /user/... for the authenticated user
+func fetchFollows(client *ghclient.Client, args []string, login string, direction string) ([]Github.RepositoryOwner, error) {
+	endpoint := constants.GH_USER_ENDPOINT + "/" + direction
+	if len(args) > 0 {
+		endpoint = fmt.Sprintf("%s/%s/%s", constants.GH_USERS_ENDPOINT, url.PathEscape(login), direction)
+	}
+
+	return ghclient.FetchAllPages[Github.RepositoryOwner](client, apiURL(endpoint+"?per_page=100"), viper.GetInt("request_sleep"))
+}
+
+// Logins added & removed between a stored sync & the current users
+func diffFollows(stored []Github.FollowModel, current []Github.RepositoryOwner) ([]string, []string) {
+	before := map[int]string{}
+	for _, f := range stored {
+		login := fmt.Sprintf("user #%d", f.UserID)
+		if f.User != nil {
+			login = f.User.Login
+		}
+		before[f.UserID] = login
+	}
+
+	var added []string
+	now := map[int]bool{}
+	for _, user := range current {
+		now[user.ID] = true
+		if _, ok := before[user.ID]; !ok {
+			added = append(added, user.Login)
+		}
+	}
+
+	var removed []string
+	for id, login := range before {
+		if !now[id] {
+			removed = append(removed, login)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+// Uppercase the first letter of a string
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"github.com/redjax/go-mygithub/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Cobra flags
+var (
+	watchingOutputFile string
+	compareFormat      string
+)
+
+// Init "watching" subcommand
+var watchingCmd = &cobra.Command{
+	Use:   "watching",
+	Short: "Operations on watched (subscribed) repositories",
+}
+
+// Init "watching get" subcommand
+var watchingGetCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get watched repositories",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Create Github API client with HTTP cache
+		client, err := newCachedGithubClient()
+		if err != nil {
+			return err
+		}
+
+		// Validate token before paging through all watched repositories
+		if err := runPreflight(client); err != nil {
+			return err
+		}
+
+		allRepos, err := ghclient.FetchAllPages[Github.Repository](client, apiURL(constants.GH_SUBSCRIPTIONS_ENDPOINT+"?per_page=100"), viper.GetInt("request_sleep"))
+		if err != nil {
+			return fmt.Errorf("error fetching watched repositories: %w", err)
+		}
+
+		fmt.Printf("Fetched %d watched repositories.\n", len(allRepos))
+
+		if saveDB {
+			// Initialize database
+			dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+			if err != nil {
+				return fmt.Errorf("error initializing database: %w", err)
+			}
+
+			// Save retrieved repositories
+			if err := db.SaveRepositories(dbConn, allRepos, Github.TrackWatching); err != nil {
+				return fmt.Errorf("error saving repositories to database: %w", err)
+			}
+			if pruneMissing {
+				if err := untrackMissingRepos(dbConn, allRepos, Github.TrackWatching); err != nil {
+					return err
+				}
+			}
+			fmt.Println("Repositories saved to database successfully.")
+		}
+
+		if saveJson {
+			// Write repositories to file
+			if err := writeJSONFile(watchingOutputFile, allRepos); err != nil {
+				return err
+			}
+			fmt.Printf("Watched repositories saved to: %s\n", watchingOutputFile)
+		}
+
+		return nil
+	},
+}
+
+// Init "watching list" subcommand
+var watchingListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List watched repositories stored in the database",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		repos, err := db.LoadRepositories(dbConn, Github.TrackWatching)
+		if err != nil {
+			return fmt.Errorf("error loading repositories from database: %w", err)
+		}
+
+		printRepoTable(repos)
+		fmt.Printf("\n%d repositories.\n", len(repos))
+
+		return nil
+	},
+}
+
+// Init "watching unwatch" subcommand
+var watchingUnwatchCmd = &cobra.Command{
+	Use:   "unwatch [owner/repo]...",
+	Short: "Stop watching repositories",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names, err := collectRepoNames(args, reposFile)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no repositories given (pass owner/repo arguments or --file)")
+		}
+
+		if dryRun {
+			for _, name := range names {
+				fmt.Printf("[dry run] Would unwatch %s\n", name)
+			}
+			return nil
+		}
+
+		// Create Github API client without the HTTP cache
+		client, err := newGithubClient(nil)
+		if err != nil {
+			return err
+		}
+
+		// Validate token before unwatching
+		if err := runPreflight(client, "repo"); err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		failed := 0
+		for i, name := range names {
+			if i > 0 {
+				// Wait before next request
+				time.Sleep(time.Duration(viper.GetInt("request_sleep")) * time.Second)
+			}
+
+			if err := client.UnwatchRepo(name); err != nil {
+				fmt.Fprintf(os.Stderr, "  %s: %v\n", name, err)
+				failed++
+				continue
+			}
+			err := db.UntrackRepository(dbConn, name, Github.TrackWatching)
+			if errors.Is(err, db.ErrRepositoryNotFound) {
+				fmt.Fprintf(os.Stderr, "  Warning: %s: unwatched, but not tracked as watched in the database\n", name)
+			} else if err != nil {
+				fmt.Fprintf(os.Stderr, "  %s: unwatched, but error updating database: %v\n", name, err)
+				failed++
+				continue
+			}
+
+			fmt.Printf("Unwatched %s\n", name)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d repositories failed", failed, len(names))
+		}
+
+		return nil
+	},
+}
+
+// Init "watching compare" subcommand
+var watchingCompareCmd = &cobra.Command{
+	Use:   "compare",
+	Short: "Show repositories that are watched but not starred, or starred but not watched",
+	Long: `Compare stored watched & starred repositories.
+
+Run "mygithub watching get --save-db" & "mygithub starred get --save-db"
+first so both are current.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := output.ValidateFormat(compareFormat, output.FormatTable, output.FormatJSON, output.FormatCSV); err != nil {
+			return err
+		}
+
+		// Initialize database
+		dbConn, err := db.InitDB(viper.GetString("db_dsn"))
+		if err != nil {
+			return fmt.Errorf("error initializing database: %w", err)
+		}
+
+		watched, err := db.LoadRepositories(dbConn, Github.TrackWatching)
+		if err != nil {
+			return fmt.Errorf("error loading watched repositories from database: %w", err)
+		}
+		starred, err := db.LoadRepositories(dbConn, Github.TrackStarred)
+		if err != nil {
+			return fmt.Errorf("error loading starred repositories from database: %w", err)
+		}
+
+		watchedOnly, starredOnly := compareRepoSets(watched, starred)
+
+		switch compareFormat {
+		case output.FormatJSON:
+			return output.WriteJSON(os.Stdout, map[string][]string{
+				"watched_not_starred": watchedOnly,
+				"starred_not_watched": starredOnly,
+			})
+		case output.FormatCSV:
+			var rows [][]string
+			for _, name := range watchedOnly {
+				rows = append(rows, []string{name, "watched_not_starred"})
+			}
+			for _, name := range starredOnly {
+				rows = append(rows, []string{name, "starred_not_watched"})
+			}
+			return output.WriteCSV(os.Stdout, []string{"full_name", "status"}, rows)
+		}
+
+		fmt.Printf("%d watched, %d starred.\n", len(watched), len(starred))
+		fmt.Printf("\nWatched but not starred (%d):\n", len(watchedOnly))
+		for _, name := range watchedOnly {
+			fmt.Printf("  %s\n", name)
+		}
+		fmt.Printf("\nStarred but not watched (%d):\n", len(starredOnly))
+		for _, name := range starredOnly {
+			fmt.Printf("  %s\n", name)
+		}
+
+		return nil
+	},
+}
+
+// "watching" CLI entrypoint
+func init() {
+	rootCmd.AddCommand(watchingCmd)
+	watchingCmd.AddCommand(watchingGetCmd)
+	watchingCmd.AddCommand(watchingListCmd)
+	watchingCmd.AddCommand(watchingUnwatchCmd)
+	watchingCmd.AddCommand(watchingCompareCmd)
+
+	// Same storage & export options as "starred get"
+	watchingGetCmd.Flags().BoolVar(&saveJson, "save-json", false, "Save response content to a file")
+	watchingGetCmd.Flags().StringVarP(&watchingOutputFile, "output", "o", "watched_repos.json", "Output file name")
+	watchingGetCmd.Flags().BoolVar(&saveDB, "save-db", false, "Save response content to a database")
+	watchingGetCmd.Flags().BoolVar(&pruneMissing, "prune-missing", false, "With --save-db, remove stored repositories that are no longer watched")
+
+	watchingUnwatchCmd.Flags().StringVarP(&reposFile, "file", "f", "", "Read owner/repo names from a file, one per line (- for stdin)")
+	watchingUnwatchCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without unwatching")
+
+	watchingCompareCmd.Flags().StringVar(&compareFormat, "format", output.FormatTable, "Output format: table, json, or csv")
+}
+
+// Names of repositories only in watched, & only in starred
+func compareRepoSets(watched []Github.RepositoryModel, starred []Github.RepositoryModel) ([]string, []string) {
+	isStarred := map[int]bool{}
+	for _, repo := range starred {
+		isStarred[repo.ID] = true
+	}
+	isWatched := map[int]bool{}
+	for _, repo := range watched {
+		isWatched[repo.ID] = true
+	}
+
+	watchedOnly := []string{}
+	for _, repo := range watched {
+		if !isStarred[repo.ID] {
+			watchedOnly = append(watchedOnly, repo.FullName)
+		}
+	}
+	starredOnly := []string{}
+	for _, repo := range starred {
+		if !isWatched[repo.ID] {
+			starredOnly = append(starredOnly, repo.FullName)
+		}
+	}
+
+	return watchedOnly, starredOnly
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Refresh installation tokens this long before they expire
+const appTokenRefreshWindow = 5 * time.Minute
+
+// Mint Github App installation tokens, refreshing them before they expire.
+// https://docs.github.com/en/apps/creating-github-apps/authenticating-with-a-github-app/authenticating-as-a-github-app-installation
+type AppTokenSource struct {
+	// Github API URL, i.e. https://api.github.com
+	BaseURL        string
+	AppID          string
+	InstallationID int64
+	Key            *rsa.PrivateKey
+	HTTP           *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// Response from the installation access token endpoint
+type installationToken struct {
+	Token       string            `json:"token"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+	Permissions map[string]string `json:"permissions"`
+}
+
+// Create a new Github App token source from a PEM-encoded private key
+func NewAppTokenSource(baseURL string, appID string, installationID int64, keyPEM []byte) (*AppTokenSource, error) {
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppTokenSource{
+		BaseURL:        strings.TrimRight(baseURL, "/"),
+		AppID:          appID,
+		InstallationID: installationID,
+		Key:            key,
+		HTTP:           http.DefaultClient,
+	}, nil
+}
+
+// Return a valid installation token, minting a new one if needed
+func (s *AppTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expires) > appTokenRefreshWindow {
+		return s.token, nil
+	}
+
+	tok, err := s.exchange()
+	if err != nil {
+		return "", err
+	}
+
+	s.token = tok.Token
+	s.expires = tok.ExpiresAt
+
+	return s.token, nil
+}
+
+// Return when the current installation token expires
+func (s *AppTokenSource) Expires() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.expires
+}
+
+// Exchange an App JWT for an installation token
+func (s *AppTokenSource) exchange() (*installationToken, error) {
+	jwt, err := s.JWT()
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", s.BaseURL, s.InstallationID)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// Set request headers
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+
+	resp, err := s.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	// Check for unexpected status
+	if resp.StatusCode != 201 {
+		return nil, fmt.Errorf("error creating installation token: unexpected status: %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+	}
+
+	var tok installationToken
+	if err := json.Unmarshal(bodyBytes, &tok); err != nil {
+		return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return &tok, nil
+}
+
+// Create a short-lived RS256 JWT identifying the App
+func (s *AppTokenSource) JWT() (string, error) {
+	now := time.Now()
+
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	claims := map[string]any{
+		// Backdate to allow for clock drift
+		"iat": now.Add(-60 * time.Second).Unix(),
+		// Github allows at most 10 minutes
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": s.AppID,
+	}
+
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(headerJSON) + "." + enc.EncodeToString(claimsJSON)
+
+	// Sign header & claims
+	digest := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing JWT: %w", err)
+	}
+
+	return unsigned + "." + enc.EncodeToString(sig), nil
+}
+
+// Parse a PKCS#1 or PKCS#8 RSA private key
+func parsePrivateKey(keyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("error parsing private key: no PEM data found")
+	}
+
+	// Github generates PKCS#1 keys
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key: %w", err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("error parsing private key: not an RSA key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Generate an App private key & its PKCS#1 PEM encoding
+func newTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// Check a JWT's RS256 signature & return its claims
+func verifyJWT(t *testing.T, jwt string, pub *rsa.PublicKey) map[string]any {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d parts, want 3", len(parts))
+	}
+
+	enc := base64.RawURLEncoding
+	sig, err := enc.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("error decoding signature: %v", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		t.Fatalf("invalid JWT signature: %v", err)
+	}
+
+	claimsJSON, err := enc.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("error decoding claims: %v", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		t.Fatalf("error parsing claims: %v", err)
+	}
+
+	return claims
+}
+
+func TestAppTokenExchange(t *testing.T) {
+	key, keyPEM := newTestKey(t)
+
+	requests := 0
+	authorization := ""
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != "POST" || r.URL.Path != "/app/installations/42/access_tokens" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		authorization = r.Header.Get("Authorization")
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(installationToken{Token: "ghs_installation", ExpiresAt: expires})
+	}))
+	defer srv.Close()
+
+	source, err := NewAppTokenSource(srv.URL+"/", "12345", 42, keyPEM)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource: %v", err)
+	}
+
+	for range 2 {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+		if token != "ghs_installation" {
+			t.Errorf("token = %q, want ghs_installation", token)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("exchanged %d times, want 1 (cached)", requests)
+	}
+
+	// The exchange authenticates with a JWT signed by the App's key
+	jwt, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok {
+		t.Fatalf("Authorization = %q, want a bearer JWT", authorization)
+	}
+	claims := verifyJWT(t, jwt, &key.PublicKey)
+	if claims["iss"] != "12345" {
+		t.Errorf("iss = %v, want 12345", claims["iss"])
+	}
+	iat, exp := int64(claims["iat"].(float64)), int64(claims["exp"].(float64))
+	if exp-iat > 600 || exp <= time.Now().Unix() {
+		t.Errorf("JWT valid from %d to %d, want a current window of at most 10 minutes", iat, exp)
+	}
+	if !source.Expires().Equal(expires) {
+		t.Errorf("Expires = %v, want %v", source.Expires(), expires)
+	}
+}
+
+func TestAppTokenRefreshesNearExpiry(t *testing.T) {
+	_, keyPEM := newTestKey(t)
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusCreated)
+		// Inside the refresh window, so every call mints a new token
+		json.NewEncoder(w).Encode(installationToken{Token: "ghs_short", ExpiresAt: time.Now().Add(time.Minute)})
+	}))
+	defer srv.Close()
+
+	source, err := NewAppTokenSource(srv.URL, "12345", 42, keyPEM)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource: %v", err)
+	}
+	for range 2 {
+		if _, err := source.Token(); err != nil {
+			t.Fatalf("Token: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("exchanged %d times, want 2", requests)
+	}
+}
+
+func TestAppTokenErrorIncludesBody(t *testing.T) {
+	_, keyPEM := newTestKey(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"A JSON web token could not be decoded"}`))
+	}))
+	defer srv.Close()
+
+	source, err := NewAppTokenSource(srv.URL, "12345", 42, keyPEM)
+	if err != nil {
+		t.Fatalf("NewAppTokenSource: %v", err)
+	}
+
+	_, err = source.Token()
+	if err == nil {
+		t.Fatal("Token succeeded, want an error")
+	}
+	if !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "could not be decoded") {
+		t.Errorf("error = %v, want the status & response body", err)
+	}
+}
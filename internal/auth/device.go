@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OAuth device flow endpoints, relative to the Github web URL
+const (
+	deviceCodeEndpoint  = "/login/device/code"
+	accessTokenEndpoint = "/login/oauth/access_token"
+	deviceGrantType     = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// Github's minimum polling interval, & how much "slow_down" adds to it
+var (
+	minPollInterval = 5 * time.Second
+	slowDownStep    = 5 * time.Second
+)
+
+// Github's OAuth device authorization flow.
+// https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/authorizing-oauth-apps#device-flow
+type DeviceFlow struct {
+	// Github web URL, i.e. https://github.com
+	BaseURL  string
+	ClientID string
+	Scopes   []string
+	HTTP     *http.Client
+}
+
+// Response from the device code endpoint
+type DeviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// Error response from the OAuth endpoints
+type oauthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+	Interval         int    `json:"interval"`
+}
+
+// Create a new device flow
+func NewDeviceFlow(baseURL string, clientID string, scopes []string) *DeviceFlow {
+	return &DeviceFlow{
+		BaseURL:  strings.TrimRight(baseURL, "/"),
+		ClientID: clientID,
+		Scopes:   scopes,
+		HTTP:     http.DefaultClient,
+	}
+}
+
+// Request a device & user code
+func (f *DeviceFlow) RequestCode() (*DeviceCode, error) {
+	form := url.Values{
+		"client_id": {f.ClientID},
+		"scope":     {strings.Join(f.Scopes, " ")},
+	}
+
+	var code DeviceCode
+	if err := f.post(deviceCodeEndpoint, form, &code); err != nil {
+		return nil, fmt.Errorf("error requesting device code: %w", err)
+	}
+	if code.DeviceCode == "" {
+		return nil, fmt.Errorf("error requesting device code: empty response")
+	}
+
+	return &code, nil
+}
+
+// Poll until the user authorizes the device, the code expires, or access is denied
+func (f *DeviceFlow) PollToken(code *DeviceCode) (*Token, error) {
+	interval := max(time.Duration(code.Interval)*time.Second, minPollInterval)
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+
+	form := url.Values{
+		"client_id":   {f.ClientID},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {deviceGrantType},
+	}
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		// Token & error responses share the endpoint, decode both
+		var resp struct {
+			Token
+			oauthError
+		}
+		if err := f.post(accessTokenEndpoint, form, &resp); err != nil {
+			return nil, fmt.Errorf("error polling for token: %w", err)
+		}
+
+		switch resp.Error {
+		case "":
+			if resp.AccessToken == "" {
+				return nil, fmt.Errorf("error polling for token: empty response")
+			}
+			return &resp.Token, nil
+		case "authorization_pending":
+			// User hasn't entered the code yet
+			continue
+		case "slow_down":
+			// Github returns the new minimum interval
+			interval = max(time.Duration(resp.Interval)*time.Second, interval+slowDownStep)
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired, run login again")
+		case "access_denied":
+			return nil, fmt.Errorf("authorization was denied")
+		default:
+			return nil, fmt.Errorf("%s: %s", resp.Error, resp.ErrorDescription)
+		}
+	}
+
+	return nil, fmt.Errorf("device code expired, run login again")
+}
+
+// POST a form to an OAuth endpoint & decode the JSON response
+func (f *DeviceFlow) post(endpoint string, form url.Values, v any) error {
+	req, err := http.NewRequest("POST", f.BaseURL+endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+
+	// Set request headers
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("error making request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Check for unexpected status
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+
+	if err := json.Unmarshal(bodyBytes, v); err != nil {
+		return fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return nil
+}
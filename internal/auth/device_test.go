@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Fake OAuth server answering token polls with responses in order
+type fakeDeviceServer struct {
+	t         *testing.T
+	responses []map[string]any
+
+	mu    sync.Mutex
+	polls []time.Time
+}
+
+func (f *fakeDeviceServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || r.Header.Get("Accept") != "application/json" {
+		f.t.Errorf("unexpected request: %s %s (Accept %q)", r.Method, r.URL.Path, r.Header.Get("Accept"))
+	}
+	if err := r.ParseForm(); err != nil {
+		f.t.Errorf("error parsing form: %v", err)
+		return
+	}
+	if r.PostForm.Get("client_id") != "client" {
+		f.t.Errorf("client_id = %q, want client", r.PostForm.Get("client_id"))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case deviceCodeEndpoint:
+		if got := r.PostForm.Get("scope"); got != "repo read:user" {
+			f.t.Errorf("scope = %q, want %q", got, "repo read:user")
+		}
+		json.NewEncoder(w).Encode(DeviceCode{DeviceCode: "dev", UserCode: "ABCD-1234", VerificationURI: "https://github.com/login/device", ExpiresIn: 60, Interval: 0})
+
+	case accessTokenEndpoint:
+		if r.PostForm.Get("device_code") != "dev" || r.PostForm.Get("grant_type") != deviceGrantType {
+			f.t.Errorf("unexpected poll form: %v", r.PostForm)
+		}
+
+		f.mu.Lock()
+		f.polls = append(f.polls, time.Now())
+		n := len(f.polls)
+		f.mu.Unlock()
+
+		if n > len(f.responses) {
+			f.t.Errorf("unexpected poll %d", n)
+			http.Error(w, "too many polls", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(f.responses[n-1])
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Poll quickly for the duration of a test
+func fastPolling(t *testing.T) {
+	interval, step := minPollInterval, slowDownStep
+	minPollInterval, slowDownStep = 10*time.Millisecond, 100*time.Millisecond
+	t.Cleanup(func() { minPollInterval, slowDownStep = interval, step })
+}
+
+// Run the device flow against a fake server answering polls with responses
+func runDeviceFlow(t *testing.T, responses ...map[string]any) (*Token, *fakeDeviceServer, error) {
+	fastPolling(t)
+
+	fake := &fakeDeviceServer{t: t, responses: responses}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	flow := NewDeviceFlow(srv.URL+"/", "client", []string{"repo", "read:user"})
+	code, err := flow.RequestCode()
+	if err != nil {
+		t.Fatalf("RequestCode: %v", err)
+	}
+	if code.UserCode != "ABCD-1234" {
+		t.Errorf("UserCode = %q, want ABCD-1234", code.UserCode)
+	}
+
+	token, err := flow.PollToken(code)
+
+	return token, fake, err
+}
+
+func TestDeviceFlowAuthorizationPending(t *testing.T) {
+	token, fake, err := runDeviceFlow(t,
+		map[string]any{"error": "authorization_pending"},
+		map[string]any{"error": "authorization_pending"},
+		map[string]any{"access_token": "gho_token", "token_type": "bearer", "scope": "repo,read:user"},
+	)
+	if err != nil {
+		t.Fatalf("PollToken: %v", err)
+	}
+	if token.AccessToken != "gho_token" || token.Scope != "repo,read:user" {
+		t.Errorf("token = %+v", token)
+	}
+	if len(fake.polls) != 3 {
+		t.Errorf("polled %d times, want 3", len(fake.polls))
+	}
+}
+
+func TestDeviceFlowSlowDown(t *testing.T) {
+	_, fake, err := runDeviceFlow(t,
+		map[string]any{"error": "authorization_pending"},
+		map[string]any{"error": "slow_down"},
+		map[string]any{"access_token": "gho_token"},
+	)
+	if err != nil {
+		t.Fatalf("PollToken: %v", err)
+	}
+	if len(fake.polls) != 3 {
+		t.Fatalf("polled %d times, want 3", len(fake.polls))
+	}
+
+	// The poll after slow_down waits the original interval plus the step
+	if gap := fake.polls[2].Sub(fake.polls[1]); gap < minPollInterval+slowDownStep {
+		t.Errorf("waited %v after slow_down, want at least %v", gap, minPollInterval+slowDownStep)
+	}
+}
+
+func TestDeviceFlowExpiredToken(t *testing.T) {
+	_, _, err := runDeviceFlow(t,
+		map[string]any{"error": "authorization_pending"},
+		map[string]any{"error": "expired_token", "error_description": "The device_code has expired."},
+	)
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("PollToken error = %v, want expired", err)
+	}
+}
+
+func TestDeviceFlowAccessDenied(t *testing.T) {
+	_, _, err := runDeviceFlow(t,
+		map[string]any{"error": "access_denied"},
+	)
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("PollToken error = %v, want denied", err)
+	}
+}
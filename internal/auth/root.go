@@ -0,0 +1,21 @@
+/* Ways of obtaining Github access tokens. */
+package auth
+
+// Token returned by Github's OAuth endpoints
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+}
+
+// Source of access tokens for API requests
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// Token source that always returns the same token, i.e. a PAT
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token() (string, error) {
+	return string(s), nil
+}
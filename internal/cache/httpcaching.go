@@ -1,7 +1,12 @@
 package cache
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/gregjones/httpcache"
@@ -12,14 +17,17 @@ import (
 type ttlCache struct {
 	httpcache.Cache
 	ttl        time.Duration
+	dir        string // disk cache directory, for the age of entries from earlier runs
+	mu         sync.Mutex
 	timestamps map[string]time.Time
 }
 
-// Create a new TTL cache
-func NewTTLCache(inner httpcache.Cache, ttl time.Duration) *ttlCache {
+// Create a new TTL cache. dir is the disk cache directory of inner, or "" if it isn't a disk cache.
+func NewTTLCache(inner httpcache.Cache, ttl time.Duration, dir string) *ttlCache {
 	return &ttlCache{
 		Cache:      inner,
 		ttl:        ttl,
+		dir:        dir,
 		timestamps: make(map[string]time.Time),
 	}
 }
@@ -35,7 +43,7 @@ func NewCachingClient(cacheDir string, cacheDurationMinutes int) *http.Client {
 		ttl = time.Duration(cacheDurationMinutes) * time.Minute
 	}
 	// Initialize new cache
-	cache := NewTTLCache(baseCache, ttl)
+	cache := NewTTLCache(baseCache, ttl, cacheDir)
 
 	// Initialize HTTP caching client
 	transport := httpcache.NewTransport(cache)
@@ -47,6 +55,9 @@ func NewCachingClient(cacheDir string, cacheDurationMinutes int) *http.Client {
 // Set a key-value pair in the cache
 func (c *ttlCache) Set(key string, resp []byte) {
 	c.Cache.Set(key, resp)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.timestamps[key] = time.Now()
 }
 
@@ -57,7 +68,14 @@ func (c *ttlCache) Get(key string) ([]byte, bool) {
 		return nil, false
 	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	t, ok := c.timestamps[key]
+	if !ok {
+		// Entry from an earlier run, aged by when it was written to disk
+		t, ok = c.diskTimestamp(key)
+	}
 
 	if !ok || time.Since(t) > c.ttl {
 		c.Cache.Delete(key)
@@ -67,3 +85,18 @@ func (c *ttlCache) Get(key string) ([]byte, bool) {
 
 	return resp, true
 }
+
+// Modification time of a key's disk cache file, named the way diskcache names it
+func (c *ttlCache) diskTimestamp(key string) (time.Time, bool) {
+	if c.dir == "" {
+		return time.Time{}, false
+	}
+
+	sum := md5.Sum([]byte(key))
+	info, err := os.Stat(filepath.Join(c.dir, hex.EncodeToString(sum[:])))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return info.ModTime(), true
+}
@@ -0,0 +1,162 @@
+package chart
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rendering options. Charts never use color, so they read the same in CI logs.
+type Options struct {
+	Width int  // total line width in columns
+	ASCII bool // plain ASCII instead of Unicode block characters
+}
+
+// Default width when the terminal size is unknown
+const DefaultWidth = 80
+
+// Narrowest width charts are drawn at
+const minWidth = 20
+
+// Longest bar label before it's truncated
+const maxLabelWidth = 24
+
+// One bar of a bar chart
+type Bar struct {
+	Label string
+	Value int
+}
+
+// Block characters, from empty to full
+var (
+	unicodeSparks = []rune("▁▂▃▄▅▆▇█")
+	asciiSparks   = []rune("_.-:=+*#")
+
+	// Partial blocks in eighths, for bar ends
+	unicodeEighths = []rune(" ▏▎▍▌▋▊▉")
+)
+
+// Write a horizontal bar chart, one bar per line, scaled to the largest value
+func Bars(w io.Writer, bars []Bar, opts Options) {
+	if len(bars) == 0 {
+		return
+	}
+
+	labelWidth, valueWidth, peak := 0, 0, 0
+	for _, b := range bars {
+		labelWidth = max(labelWidth, utf8.RuneCountInString(truncate(b.Label, maxLabelWidth, opts.ASCII)))
+		valueWidth = max(valueWidth, len(fmt.Sprint(b.Value)))
+		peak = max(peak, b.Value)
+	}
+
+	// "  label  ███ value"
+	barWidth := max(width(opts)-labelWidth-valueWidth-5, 1)
+
+	for _, b := range bars {
+		label := truncate(b.Label, maxLabelWidth, opts.ASCII)
+		pad := strings.Repeat(" ", labelWidth-utf8.RuneCountInString(label))
+		fmt.Fprintf(w, "  %s%s  %s %d\n", label, pad, bar(b.Value, peak, barWidth, opts.ASCII), b.Value)
+	}
+}
+
+// Render values as a one-line sparkline at most width columns wide.
+// Values are summed into buckets when there are more than fit; the number of
+// values per column is returned with the line.
+func Sparkline(values []int, opts Options) (string, int) {
+	if len(values) == 0 {
+		return "", 0
+	}
+
+	perColumn := (len(values) + width(opts) - 1) / width(opts)
+	values = Resample(values, perColumn)
+
+	sparks := unicodeSparks
+	if opts.ASCII {
+		sparks = asciiSparks
+	}
+
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case v <= 0:
+			b.WriteRune(' ')
+		default:
+			// Any nonzero value shows at least the lowest mark
+			b.WriteRune(sparks[(v*len(sparks)-1)/peak])
+		}
+	}
+
+	return b.String(), perColumn
+}
+
+// Sum every n consecutive values
+func Resample(values []int, n int) []int {
+	if n <= 1 {
+		return values
+	}
+
+	out := make([]int, 0, (len(values)+n-1)/n)
+	for i := 0; i < len(values); i += n {
+		sum := 0
+		for _, v := range values[i:min(i+n, len(values))] {
+			sum += v
+		}
+		out = append(out, sum)
+	}
+
+	return out
+}
+
+// Draw a bar of value scaled to peak over columns
+func bar(value int, peak int, columns int, ascii bool) string {
+	if peak <= 0 || value <= 0 {
+		return ""
+	}
+
+	// Eighths of a column, so short bars still differ
+	eighths := value * columns * 8 / peak
+	if eighths == 0 {
+		eighths = 1
+	}
+
+	if ascii {
+		return strings.Repeat("#", max(eighths/8, 1))
+	}
+
+	s := strings.Repeat(string(unicodeSparks[len(unicodeSparks)-1]), eighths/8)
+	if rem := eighths % 8; rem > 0 {
+		s += string(unicodeEighths[rem])
+	}
+
+	return s
+}
+
+// Shorten s to at most n columns
+func truncate(s string, n int, ascii bool) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	ellipsis := "…"
+	if ascii {
+		ellipsis = "..."
+	}
+	runes := []rune(s)
+
+	return string(runes[:n-utf8.RuneCountInString(ellipsis)]) + ellipsis
+}
+
+// Usable width of opts, with defaults for unset or tiny widths
+func width(opts Options) int {
+	if opts.Width <= 0 {
+		return DefaultWidth
+	}
+
+	return max(opts.Width, minWidth)
+}
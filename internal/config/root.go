@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Name of the app's config directory & default config file
+const (
+	configDirName  = "mygithub"
+	configFileName = "config.yaml"
+)
+
+// Return the app's config directory (~/.config/mygithub)
+func Dir() (string, error) {
+	// Respect XDG_CONFIG_HOME if set
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, configDirName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".config", configDirName), nil
+}
+
+// Return the default config file path (~/.config/mygithub/config.yaml)
+func DefaultConfigFile() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, configFileName), nil
+}
+
+// Read the config file into viper & apply the selected profile.
+//
+// An empty cfgFile falls back to the default path, which may not exist.
+// An explicit cfgFile that does not exist is an error.
+func Load(v *viper.Viper, cfgFile string) error {
+	explicit := cfgFile != ""
+
+	if !explicit {
+		path, err := DefaultConfigFile()
+		if err != nil {
+			return err
+		}
+		cfgFile = path
+	}
+
+	// Skip a missing default config file
+	if _, err := os.Stat(cfgFile); err != nil {
+		if errors.Is(err, os.ErrNotExist) && !explicit {
+			// A profile can't be selected without a config file to define it
+			if profile := v.GetString("profile"); profile != "" {
+				return fmt.Errorf("profile %q requested but no config file found at %s", profile, cfgFile)
+			}
+			return nil
+		}
+		return fmt.Errorf("error reading config file: %w", err)
+	}
+
+	// Read config file
+	v.SetConfigFile(cfgFile)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("error reading config file %s: %w", cfgFile, err)
+	}
+
+	// Priority: --profile flag > MYGITHUB_PROFILE env > "profile" key in config
+	profile := v.GetString("profile")
+	if profile == "" {
+		return nil
+	}
+
+	return ApplyProfile(v, profile)
+}
+
+// Merge a named profile's settings over the top-level config values
+func ApplyProfile(v *viper.Viper, profile string) error {
+	key := "profiles." + profile
+	if !v.IsSet(key) {
+		return fmt.Errorf("profile %q not found in config file %s (available: %s)", profile, v.ConfigFileUsed(), strings.Join(Profiles(v), ", "))
+	}
+
+	if err := v.MergeConfigMap(v.GetStringMap(key)); err != nil {
+		return fmt.Errorf("error applying profile %q: %w", profile, err)
+	}
+
+	return nil
+}
+
+// Return the names of all profiles defined in the config
+func Profiles(v *viper.Viper) []string {
+	var names []string
+	for name := range v.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
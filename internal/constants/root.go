@@ -1,7 +1,55 @@
 package constants
 
-// URL for requesting user's starred repositories
-var GH_STARRED_URL = "https://api.github.com/user/starred"
+// Base URL for the Github REST API
+var GH_API_URL = "https://api.github.com"
+
+// Endpoint for requesting user's starred repositories
+var GH_STARRED_ENDPOINT = "/user/starred"
 
 // Default "Accept: ..." header value
 var GH_API_ACCCEPT_HEADER = "application/vnd.github+json"
+
+// Default SQLite database file
+var DEFAULT_DB_DSN = "mygithub.db"
+
+// Endpoint for the authenticated user
+var GH_USER_ENDPOINT = "/user"
+
+// Base URL for Github's web & OAuth endpoints
+var GH_WEB_URL = "https://github.com"
+
+// Endpoint for the authenticated user's rate limits
+var GH_RATE_LIMIT_ENDPOINT = "/rate_limit"
+
+// Endpoint prefix for a single repository, i.e. /repos/{owner}/{repo}
+var GH_REPOS_ENDPOINT = "/repos"
+
+// Endpoint for the GraphQL API, relative to the REST API URL
+var GH_GRAPHQL_ENDPOINT = "/graphql"
+
+// "Accept: ..." header value that adds starred_at to starred repositories
+var GH_STAR_ACCEPT_HEADER = "application/vnd.github.star+json"
+
+// Endpoint for repositories the authenticated user can access
+var GH_USER_REPOS_ENDPOINT = "/user/repos"
+
+// Endpoint for an organization's repositories, formatted with the org login
+var GH_ORG_REPOS_ENDPOINT = "/orgs/%s/repos"
+
+// Endpoint for the authenticated user's membership in an organization, formatted with the org login
+var GH_ORG_MEMBERSHIP_ENDPOINT = "/user/memberships/orgs/%s"
+
+// Endpoint prefix for a user by login, i.e. /users/{login}
+var GH_USERS_ENDPOINT = "/users"
+
+// Endpoint for the authenticated user's gists, & a single gist at /gists/{id}
+var GH_GISTS_ENDPOINT = "/gists"
+
+// Endpoint for repositories the authenticated user is watching
+var GH_SUBSCRIPTIONS_ENDPOINT = "/user/subscriptions"
+
+// Endpoint for the authenticated user's notifications, & threads at /notifications/threads/{id}
+var GH_NOTIFICATIONS_ENDPOINT = "/notifications"
+
+// "Accept: ..." header value that returns file contents, i.e. a README, as-is
+var GH_RAW_ACCEPT_HEADER = "application/vnd.github.raw+json"
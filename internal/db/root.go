@@ -1,9 +1,16 @@
 package db
 
 import (
+	"bytes"
+	"compress/gzip"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -12,6 +19,9 @@ import (
 	"github.com/redjax/go-mygithub/internal/domain/Github"
 )
 
+// Returned when no stored repository matches an "owner/repo" name
+var ErrRepositoryNotFound = errors.New("repository not found in database")
+
 // Model for Github repository owner
 func ConvertOwnerToModel(owner Github.RepositoryOwner) Github.RepositoryOwnerModel {
 	return Github.RepositoryOwnerModel{
@@ -162,6 +172,178 @@ func ConvertRepositoryToModel(repo Github.Repository) Github.RepositoryModel {
 		DefaultBranch:            repo.DefaultBranch,
 		PermissionsID:            nil, // set after Permissions saved
 		Permissions:              permModel,
+		StarredAt:                toNullTime(repo.StarredAt),
+	}
+}
+
+// Schema from a Github repository model, i.e. for exporting stored repositories
+func ConvertModelToRepository(model Github.RepositoryModel) Github.Repository {
+	var topics []string
+	if len(model.Topics) > 0 {
+		_ = json.Unmarshal(model.Topics, &topics) // unmarshal topics JSON array
+	}
+
+	return Github.Repository{
+		ID:                       model.ID,
+		NodeID:                   model.NodeID,
+		Name:                     model.Name,
+		FullName:                 model.FullName,
+		Private:                  model.Private,
+		Owner:                    ConvertModelToOwner(model.Owner),
+		HTMLURL:                  model.HTMLURL,
+		Description:              fromNullString(model.Description),
+		Fork:                     model.Fork,
+		URL:                      model.URL,
+		ForksURL:                 model.ForksURL,
+		KeysURL:                  model.KeysURL,
+		CollaboratorsURL:         model.CollaboratorsURL,
+		TeamsURL:                 model.TeamsURL,
+		HooksURL:                 model.HooksURL,
+		IssueEventsURL:           model.IssueEventsURL,
+		EventsURL:                model.EventsURL,
+		AssigneesURL:             model.AssigneesURL,
+		BranchesURL:              model.BranchesURL,
+		TagsURL:                  model.TagsURL,
+		BlobsURL:                 model.BlobsURL,
+		GitTagsURL:               model.GitTagsURL,
+		GitRefsURL:               model.GitRefsURL,
+		TreesURL:                 model.TreesURL,
+		StatusesURL:              model.StatusesURL,
+		LanguagesURL:             model.LanguagesURL,
+		StargazersURL:            model.StargazersURL,
+		ContributorsURL:          model.ContributorsURL,
+		SubscribersURL:           model.SubscribersURL,
+		SubscriptionURL:          model.SubscriptionURL,
+		CommitsURL:               model.CommitsURL,
+		GitCommitsURL:            model.GitCommitsURL,
+		CommentsURL:              model.CommentsURL,
+		IssueCommentURL:          model.IssueCommentURL,
+		ContentsURL:              model.ContentsURL,
+		CompareURL:               model.CompareURL,
+		MergesURL:                model.MergesURL,
+		ArchiveURL:               model.ArchiveURL,
+		DownloadsURL:             model.DownloadsURL,
+		IssuesURL:                model.IssuesURL,
+		PullsURL:                 model.PullsURL,
+		MilestonesURL:            model.MilestonesURL,
+		NotificationsURL:         model.NotificationsURL,
+		LabelsURL:                model.LabelsURL,
+		ReleasesURL:              model.ReleasesURL,
+		DeploymentsURL:           model.DeploymentsURL,
+		CreatedAt:                model.CreatedAt,
+		UpdatedAt:                model.UpdatedAt,
+		PushedAt:                 model.PushedAt,
+		GitURL:                   model.GitURL,
+		SshURL:                   model.SshURL,
+		CloneURL:                 model.CloneURL,
+		SvnURL:                   model.SvnURL,
+		Homepage:                 fromNullString(model.Homepage),
+		Size:                     model.Size,
+		StargazersCount:          model.StargazersCount,
+		WatchersCount:            model.WatchersCount,
+		Language:                 fromNullString(model.Language),
+		HasIssues:                model.HasIssues,
+		HasProjects:              model.HasProjects,
+		HasDownloads:             model.HasDownloads,
+		HasWiki:                  model.HasWiki,
+		HasPages:                 model.HasPages,
+		HasDiscussions:           model.HasDiscussions,
+		ForksCount:               model.ForksCount,
+		MirrorURL:                fromNullString(model.MirrorURL),
+		Archived:                 model.Archived,
+		Disabled:                 model.Disabled,
+		OpenIssuesCount:          model.OpenIssuesCount,
+		License:                  ConvertModelToLicense(model.License),
+		AllowForking:             model.AllowForking,
+		IsTemplate:               model.IsTemplate,
+		WebCommitSignoffRequired: model.WebCommitSignoffRequired,
+		Topics:                   topics,
+		Visibility:               model.Visibility,
+		Forks:                    model.Forks,
+		OpenIssues:               model.OpenIssues,
+		Watchers:                 model.Watchers,
+		DefaultBranch:            model.DefaultBranch,
+		Permissions:              ConvertModelToPermissions(model.Permissions),
+		StarredAt:                fromNullTime(model.StarredAt),
+	}
+}
+
+// Schema from a Github repository owner model
+func ConvertModelToOwner(owner *Github.RepositoryOwnerModel) Github.RepositoryOwner {
+	if owner == nil {
+		return Github.RepositoryOwner{}
+	}
+	return Github.RepositoryOwner{
+		Login:             owner.Login,
+		ID:                owner.ID,
+		NodeID:            owner.NodeID,
+		AvatarURL:         owner.AvatarURL,
+		GravatarID:        owner.GravatarID,
+		URL:               owner.URL,
+		HTMLURL:           owner.HTMLURL,
+		FollowersURL:      owner.FollowersURL,
+		FollowingURL:      owner.FollowingURL,
+		GistsURL:          owner.GistsURL,
+		StarredURL:        owner.StarredURL,
+		SubscriptionsURL:  owner.SubscriptionsURL,
+		OrganizationsURL:  owner.OrganizationsURL,
+		ReposURL:          owner.ReposURL,
+		EventsURL:         owner.EventsURL,
+		ReceivedEventsURL: owner.ReceivedEventsURL,
+		Type:              owner.Type,
+		UserViewType:      owner.UserViewType,
+		SiteAdmin:         owner.SiteAdmin,
+	}
+}
+
+// Schema from a Github repository license model
+func ConvertModelToLicense(license *Github.RepositoryLicenseModel) *Github.RepositoryLicense {
+	if license == nil {
+		return nil
+	}
+	return &Github.RepositoryLicense{
+		Key:    fromNullString(license.Key),
+		Name:   fromNullString(license.Name),
+		SPDXID: fromNullString(license.SPDXID),
+		URL:    fromNullString(license.URL),
+		NodeID: fromNullString(license.NodeID),
+	}
+}
+
+// Schema from a Github repository permissions model
+func ConvertModelToPermissions(perm *Github.RepositoryPermissionsModel) *Github.RepositoryPermissions {
+	if perm == nil {
+		return nil
+	}
+	return &Github.RepositoryPermissions{
+		Admin:    fromNullBool(perm.Admin),
+		Maintain: fromNullBool(perm.Maintain),
+		Push:     fromNullBool(perm.Push),
+		Triage:   fromNullBool(perm.Triage),
+		Pull:     fromNullBool(perm.Pull),
+	}
+}
+
+// Model for a Github star list & its items
+func ConvertStarListToModel(list Github.StarList) Github.StarListModel {
+	items := make([]Github.StarListItemModel, len(list.Items))
+	for i, item := range list.Items {
+		items[i] = Github.StarListItemModel{
+			StarListID:   list.ID,
+			RepositoryID: item.RepositoryID,
+			FullName:     item.FullName,
+		}
+	}
+
+	return Github.StarListModel{
+		ID:          list.ID,
+		Name:        list.Name,
+		Slug:        list.Slug,
+		Description: toNullString(list.Description),
+		IsPrivate:   list.IsPrivate,
+		CreatedAt:   list.CreatedAt,
+		UpdatedAt:   list.UpdatedAt,
+		Items:       items,
 	}
 }
 
@@ -181,8 +363,59 @@ func toNullBool(b *bool) sql.NullBool {
 	return sql.NullBool{Bool: *b, Valid: true}
 }
 
-// Save Repository models to database
-func SaveRepositories(db *gorm.DB, repos []Github.Repository) error {
+// Helper to convert *time.Time to sql.NullTime
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// Helper to convert sql.NullString to *string
+func fromNullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// Helper to convert sql.NullBool to *bool
+func fromNullBool(b sql.NullBool) *bool {
+	if !b.Valid {
+		return nil
+	}
+	return &b.Bool
+}
+
+// Helper to convert sql.NullTime to *time.Time
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// Save Repository models to database, recording why they're tracked (i.e. Github.TrackStarred)
+func SaveRepositories(db *gorm.DB, repos []Github.Repository, reason string) error {
+	return saveRepositories(db, repos, reason, nil)
+}
+
+// Repository columns the GraphQL API has no field for
+var graphQLMissingColumns = []string{"has_downloads", "has_pages"}
+
+// Save repositories fetched with the GraphQL API, keeping the stored values
+// of columns it can't fill instead of resetting them
+func SaveGraphQLRepositories(db *gorm.DB, repos []Github.Repository, reason string) error {
+	return saveRepositories(db, repos, reason, graphQLMissingColumns)
+}
+
+// Save repositories, leaving the keep columns of existing rows unchanged
+func saveRepositories(db *gorm.DB, repos []Github.Repository, reason string, keep []string) error {
+	upsert, err := repositoryUpsert(db, keep)
+	if err != nil {
+		return err
+	}
+
 	for i, repo := range repos {
 		// Convert schemas to model
 		model := ConvertRepositoryToModel(repo)
@@ -209,11 +442,34 @@ func SaveRepositories(db *gorm.DB, repos []Github.Repository) error {
 			model.PermissionsID = &model.Permissions.ID
 		}
 
-		// Save repo if it doesn't exist
-		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model).Error; err != nil {
+		// Save repo, updating it if it already exists
+		if err := db.Clauses(upsert).Create(&model).Error; err != nil {
 			return fmt.Errorf("repo %d (main): %w", i+1, err)
 		}
 
+		// Save latest release if fetched with the repo (GraphQL API)
+		if repo.LatestRelease != nil {
+			if err := SaveRelease(db, repo.ID, *repo.LatestRelease); err != nil {
+				return fmt.Errorf("repo %d (release): %w", i+1, err)
+			}
+		}
+
+		// Save languages if fetched with the repo (GraphQL API), so enrichment can skip it
+		if repo.Languages != nil {
+			if err := SaveLanguages(db, repo.ID, repo.Languages); err != nil {
+				return fmt.Errorf("repo %d (languages): %w", i+1, err)
+			}
+			if err := SetEnriched(db, Github.EnrichLanguages, model); err != nil {
+				return fmt.Errorf("repo %d (languages): %w", i+1, err)
+			}
+		}
+
+		// Record why the repo is tracked
+		tracking := Github.RepositoryTrackingModel{RepositoryID: model.ID, Reason: reason}
+		if err := db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&tracking).Error; err != nil {
+			return fmt.Errorf("repo %d (tracking): %w", i+1, err)
+		}
+
 		// Log every 100 repos
 		if (i+1)%100 == 0 || i == len(repos)-1 {
 			fmt.Printf("  Saved %d/%d repositories to DB...\n", i+1, len(repos))
@@ -223,24 +479,628 @@ func SaveRepositories(db *gorm.DB, repos []Github.Repository) error {
 	return nil
 }
 
+// Build an upsert clause that refreshes every repository column except keep, keeping
+// the stored starred_at when the new data doesn't have one (i.e. from /repos/{owner}/{repo})
+func repositoryUpsert(db *gorm.DB, keep []string) (clause.OnConflict, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&Github.RepositoryModel{}); err != nil {
+		return clause.OnConflict{}, fmt.Errorf("parsing repository model: %w", err)
+	}
+
+	var columns []string
+	for _, field := range stmt.Schema.Fields {
+		if field.DBName == "" || field.PrimaryKey || field.DBName == "starred_at" || slices.Contains(keep, field.DBName) {
+			continue
+		}
+		columns = append(columns, field.DBName)
+	}
+
+	updates := clause.AssignmentColumns(columns)
+	updates = append(updates, clause.Assignment{
+		Column: clause.Column{Name: "starred_at"},
+		Value:  gorm.Expr("COALESCE(excluded.starred_at, repository_models.starred_at)"),
+	})
+
+	return clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: updates,
+	}, nil
+}
+
+// Load stored repositories with their owner, license, & permissions.
+// Only repositories tracked for reason are loaded, or all of them if reason is "".
+func LoadRepositories(db *gorm.DB, reason string) ([]Github.RepositoryModel, error) {
+	var repos []Github.RepositoryModel
+
+	query := db.Preload("Owner").Preload("License").Preload("Permissions")
+	if reason != "" {
+		query = query.Where("id IN (?)", db.Model(&Github.RepositoryTrackingModel{}).Select("repository_id").Where("reason = ?", reason))
+	}
+	err := query.Order("full_name").Find(&repos).Error
+
+	return repos, err
+}
+
+// Load stored repositories that are members of a star list, by list name or slug
+func LoadRepositoriesInList(db *gorm.DB, list string) ([]Github.RepositoryModel, error) {
+	var repos []Github.RepositoryModel
+	err := db.Preload("Owner").Preload("License").Preload("Permissions").
+		Joins("JOIN star_list_item_models ON star_list_item_models.repository_id = repository_models.id").
+		Joins("JOIN star_list_models ON star_list_models.id = star_list_item_models.star_list_id").
+		Where("LOWER(star_list_models.name) = LOWER(?) OR star_list_models.slug = ?", list, list).
+		Order("repository_models.full_name").
+		Find(&repos).Error
+
+	return repos, err
+}
+
+// Replace all stored star lists & memberships with the given lists
+func SaveStarLists(db *gorm.DB, lists []Github.StarList) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Lists & memberships are replaced wholesale, so removed lists & items don't linger
+		if err := tx.Where("1 = 1").Delete(&Github.StarListItemModel{}).Error; err != nil {
+			return fmt.Errorf("clearing star list items: %w", err)
+		}
+		if err := tx.Where("1 = 1").Delete(&Github.StarListModel{}).Error; err != nil {
+			return fmt.Errorf("clearing star lists: %w", err)
+		}
+
+		for _, list := range lists {
+			model := ConvertStarListToModel(list)
+			if err := tx.Omit("Items").Create(&model).Error; err != nil {
+				return fmt.Errorf("list %q: %w", list.Name, err)
+			}
+			if len(model.Items) > 0 {
+				if err := tx.Omit("Repository").Create(&model.Items).Error; err != nil {
+					return fmt.Errorf("list %q (items): %w", list.Name, err)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+// Stop tracking a repository for a reason by its "owner/repo" name, matched case-insensitively.
+// The repository is deleted once nothing tracks it. Returns ErrRepositoryNotFound if it isn't tracked for reason.
+func UntrackRepository(db *gorm.DB, fullName string, reason string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var repoIDs []int
+		err := tx.Model(&Github.RepositoryTrackingModel{}).
+			Where("reason = ? AND repository_id IN (?)", reason, repositoryIDsByName(tx, fullName)).
+			Pluck("repository_id", &repoIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(repoIDs) == 0 {
+			return ErrRepositoryNotFound
+		}
+
+		return untrackRepositoryIDs(tx, repoIDs, reason)
+	})
+}
+
+// Query the IDs of stored repositories by "owner/repo" name, matched case-insensitively like GitHub does
+func repositoryIDsByName(db *gorm.DB, fullName string) *gorm.DB {
+	return db.Model(&Github.RepositoryModel{}).Select("id").Where("LOWER(full_name) = LOWER(?)", fullName)
+}
+
+// Stop tracking repositories for a reason unless their ID is in keep, i.e. after a full sync.
+// Returns how many repositories were untracked.
+func UntrackMissing(db *gorm.DB, reason string, keep []int) (int, error) {
+	var repoIDs []int
+	err := db.Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&Github.RepositoryTrackingModel{}).Where("reason = ?", reason)
+		if len(keep) > 0 {
+			query = query.Where("repository_id NOT IN ?", keep)
+		}
+		if err := query.Pluck("repository_id", &repoIDs).Error; err != nil {
+			return err
+		}
+
+		return untrackRepositoryIDs(tx, repoIDs, reason)
+	})
+
+	return len(repoIDs), err
+}
+
+// Delete tracking rows for a reason & any repositories left with no reasons
+func untrackRepositoryIDs(tx *gorm.DB, repoIDs []int, reason string) error {
+	if len(repoIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("repository_id IN ? AND reason = ?", repoIDs, reason).Delete(&Github.RepositoryTrackingModel{}).Error; err != nil {
+		return err
+	}
+
+	// Unstarred repositories are removed from star lists too
+	if reason == Github.TrackStarred {
+		if err := tx.Where("repository_id IN ?", repoIDs).Delete(&Github.StarListItemModel{}).Error; err != nil {
+			return err
+		}
+	}
+
+	// Delete repositories with no reasons left, & their enrichment data
+	var orphanIDs []int
+	tracked := tx.Model(&Github.RepositoryTrackingModel{}).Select("repository_id")
+	if err := tx.Model(&Github.RepositoryModel{}).Where("id IN ? AND id NOT IN (?)", repoIDs, tracked).Pluck("id", &orphanIDs).Error; err != nil {
+		return err
+	}
+	if len(orphanIDs) == 0 {
+		return nil
+	}
+
+	for _, model := range []any{&Github.ReleaseModel{}, &Github.RepositoryLanguageModel{}, &Github.ReadmeModel{}, &Github.RepositoryContributorsModel{}, &Github.EnrichmentStateModel{}} {
+		if err := tx.Where("repository_id IN ?", orphanIDs).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Where("id IN ?", orphanIDs).Delete(&Github.RepositoryModel{}).Error
+}
+
+// Replace a user's stored followers or following with the given users
+func SaveFollows(db *gorm.DB, login string, direction string, users []Github.RepositoryOwner) error {
+	login = strings.ToLower(login)
+	now := time.Now()
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("login = ? AND direction = ?", login, direction).Delete(&Github.FollowModel{}).Error; err != nil {
+			return fmt.Errorf("clearing %s: %w", direction, err)
+		}
+
+		for _, user := range users {
+			// Save or refresh the user
+			owner := ConvertOwnerToModel(user)
+			if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&owner).Error; err != nil {
+				return fmt.Errorf("user %s: %w", user.Login, err)
+			}
+
+			follow := Github.FollowModel{Login: login, Direction: direction, UserID: owner.ID, SyncedAt: now}
+			if err := tx.Omit("User").Create(&follow).Error; err != nil {
+				return fmt.Errorf("user %s (%s): %w", user.Login, direction, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Load a user's stored followers or following, from the last sync
+func LoadFollows(db *gorm.DB, login string, direction string) ([]Github.FollowModel, error) {
+	var follows []Github.FollowModel
+	err := db.Preload("User").Where("login = ? AND direction = ?", strings.ToLower(login), direction).Find(&follows).Error
+
+	return follows, err
+}
+
+// Model for a gist & its files
+func ConvertGistToModel(gist Github.Gist) Github.GistModel {
+	model := Github.GistModel{
+		ID:          gist.ID,
+		NodeID:      gist.NodeID,
+		HTMLURL:     gist.HTMLURL,
+		Description: toNullString(gist.Description),
+		Public:      gist.Public,
+		Comments:    gist.Comments,
+		CreatedAt:   gist.CreatedAt,
+		UpdatedAt:   gist.UpdatedAt,
+	}
+	if gist.Owner != nil {
+		model.OwnerLogin = gist.Owner.Login
+	}
+
+	for _, file := range gist.Files {
+		model.Files = append(model.Files, Github.GistFileModel{
+			GistID:   gist.ID,
+			Filename: file.Filename,
+			Type:     file.Type,
+			Language: toNullString(file.Language),
+			Size:     file.Size,
+			Content:  file.Content,
+		})
+	}
+
+	return model
+}
+
+// Save a gist & replace its stored files
+func SaveGist(db *gorm.DB, gist Github.Gist) error {
+	model := ConvertGistToModel(gist)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Omit("Files").Create(&model).Error; err != nil {
+			return fmt.Errorf("gist %s: %w", gist.ID, err)
+		}
+
+		// Files renamed or removed from the gist don't linger
+		if err := tx.Where("gist_id = ?", gist.ID).Delete(&Github.GistFileModel{}).Error; err != nil {
+			return fmt.Errorf("gist %s (clearing files): %w", gist.ID, err)
+		}
+		if len(model.Files) > 0 {
+			if err := tx.Create(&model.Files).Error; err != nil {
+				return fmt.Errorf("gist %s (files): %w", gist.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Map of stored gist IDs to their Github updated_at, for incremental backups
+func LoadGistUpdatedAt(db *gorm.DB) (map[string]time.Time, error) {
+	var gists []Github.GistModel
+	if err := db.Select("id", "updated_at").Find(&gists).Error; err != nil {
+		return nil, err
+	}
+
+	updated := make(map[string]time.Time, len(gists))
+	for _, g := range gists {
+		updated[g.ID] = g.UpdatedAt
+	}
+
+	return updated, nil
+}
+
+// Model for a notification thread
+func ConvertNotificationToModel(n Github.Notification) Github.NotificationModel {
+	return Github.NotificationModel{
+		ID:           n.ID,
+		RepositoryID: n.Repository.ID,
+		FullName:     n.Repository.FullName,
+		SubjectTitle: n.Subject.Title,
+		SubjectType:  n.Subject.Type,
+		SubjectURL:   toNullString(n.Subject.URL),
+		Reason:       n.Reason,
+		Unread:       n.Unread,
+		UpdatedAt:    n.UpdatedAt,
+		LastReadAt:   toNullTime(n.LastReadAt),
+		FirstSeenAt:  time.Now(),
+	}
+}
+
+// Save notification threads, updating ones already stored
+func SaveNotifications(db *gorm.DB, notifications []Github.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	models := make([]Github.NotificationModel, len(notifications))
+	for i, n := range notifications {
+		models[i] = ConvertNotificationToModel(n)
+	}
+
+	// Keep first_seen_at from when the thread was first stored
+	upsert := clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"repository_id", "full_name", "subject_title", "subject_type", "subject_url",
+			"reason", "unread", "updated_at", "last_read_at",
+		}),
+	}
+	if err := db.Clauses(upsert).CreateInBatches(&models, 100).Error; err != nil {
+		return fmt.Errorf("saving notifications: %w", err)
+	}
+
+	return nil
+}
+
+// Mark stored notification threads as read
+func MarkNotificationsRead(db *gorm.DB, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return db.Model(&Github.NotificationModel{}).Where("id IN ?", ids).
+		Updates(map[string]any{"unread": false, "last_read_at": time.Now()}).Error
+}
+
+// Load stored notification threads updated since a time, newest first
+func LoadNotifications(db *gorm.DB, since time.Time) ([]Github.NotificationModel, error) {
+	var notifications []Github.NotificationModel
+	err := db.Where("updated_at >= ?", since).Order("updated_at DESC").Find(&notifications).Error
+
+	return notifications, err
+}
+
+// Model for a repository's release
+func ConvertReleaseToModel(repoID int, release Github.Release) Github.ReleaseModel {
+	return Github.ReleaseModel{
+		ID:           release.ID,
+		RepositoryID: repoID,
+		NodeID:       release.NodeID,
+		TagName:      release.TagName,
+		Name:         toNullString(release.Name),
+		Body:         toNullString(release.Body),
+		HTMLURL:      release.HTMLURL,
+		Draft:        release.Draft,
+		Prerelease:   release.Prerelease,
+		CreatedAt:    release.CreatedAt,
+		PublishedAt:  toNullTime(release.PublishedAt),
+	}
+}
+
+// Save a repository's release, updating it if already stored
+func SaveRelease(db *gorm.DB, repoID int, release Github.Release) error {
+	model := ConvertReleaseToModel(repoID, release)
+
+	// GraphQL releases have no body, don't overwrite one fetched from REST
+	upsert := clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: append(
+			clause.AssignmentColumns([]string{"repository_id", "node_id", "tag_name", "name", "html_url", "draft", "prerelease", "created_at", "published_at"}),
+			clause.Assignment{Column: clause.Column{Name: "body"}, Value: gorm.Expr("COALESCE(excluded.body, release_models.body)")},
+		),
+	}
+
+	return db.Clauses(upsert).Create(&model).Error
+}
+
+// Load releases published after a time, newest first, with their repository's name
+func LoadReleasesSince(db *gorm.DB, since time.Time) ([]Github.ReleaseModel, map[int]string, error) {
+	var releases []Github.ReleaseModel
+	err := db.Where("published_at > ? AND draft = ?", since, false).Order("published_at DESC").Find(&releases).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names, err := releaseRepositoryNames(db, releases)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return releases, names, nil
+}
+
+// Load the newest published releases, up to limit, with their repository's name
+func LoadLatestReleases(db *gorm.DB, limit int) ([]Github.ReleaseModel, map[int]string, error) {
+	var releases []Github.ReleaseModel
+	err := db.Where("published_at IS NOT NULL AND draft = ?", false).Order("published_at DESC, id DESC").Limit(limit).Find(&releases).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names, err := releaseRepositoryNames(db, releases)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return releases, names, nil
+}
+
+// Map of repository IDs to names for a set of releases
+func releaseRepositoryNames(db *gorm.DB, releases []Github.ReleaseModel) (map[int]string, error) {
+	ids := make([]int, len(releases))
+	for i, r := range releases {
+		ids[i] = r.RepositoryID
+	}
+
+	var repos []Github.RepositoryModel
+	if err := db.Select("id", "full_name").Where("id IN ?", ids).Find(&repos).Error; err != nil {
+		return nil, err
+	}
+	names := make(map[int]string, len(repos))
+	for _, repo := range repos {
+		names[repo.ID] = repo.FullName
+	}
+
+	return names, nil
+}
+
+// Load the most recently starred repositories, up to limit
+func LoadRecentlyStarred(db *gorm.DB, limit int) ([]Github.RepositoryModel, error) {
+	var repos []Github.RepositoryModel
+	err := db.Preload("Owner").
+		Where("starred_at IS NOT NULL").
+		Where("id IN (?)", db.Model(&Github.RepositoryTrackingModel{}).Select("repository_id").Where("reason = ?", Github.TrackStarred)).
+		Order("starred_at DESC, id DESC").Limit(limit).Find(&repos).Error
+
+	return repos, err
+}
+
+// When a named command last ran, or zero if it never has
+func GetLastRun(db *gorm.DB, name string) (time.Time, error) {
+	var state Github.SyncStateModel
+	err := db.Where("name = ?", name).Limit(1).Find(&state).Error
+
+	return state.LastRunAt, err
+}
+
+// Record when a named command last ran
+func SetLastRun(db *gorm.DB, name string, at time.Time) error {
+	state := Github.SyncStateModel{Name: name, LastRunAt: at}
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&state).Error
+}
+
+// Replace a repository's stored languages
+func SaveLanguages(db *gorm.DB, repoID int, languages map[string]int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", repoID).Delete(&Github.RepositoryLanguageModel{}).Error; err != nil {
+			return err
+		}
+
+		if len(languages) == 0 {
+			return nil
+		}
+
+		models := make([]Github.RepositoryLanguageModel, 0, len(languages))
+		for language, bytes := range languages {
+			models = append(models, Github.RepositoryLanguageModel{RepositoryID: repoID, Language: language, Bytes: bytes})
+		}
+
+		return tx.Create(&models).Error
+	})
+}
+
+// Load stored languages of repositories tracked for reason, or all if reason is ""
+func LoadLanguages(db *gorm.DB, reason string) ([]Github.RepositoryLanguageModel, error) {
+	var languages []Github.RepositoryLanguageModel
+
+	query := db.Model(&Github.RepositoryLanguageModel{})
+	if reason != "" {
+		query = query.Where("repository_id IN (?)", db.Model(&Github.RepositoryTrackingModel{}).Select("repository_id").Where("reason = ?", reason))
+	}
+	err := query.Order("repository_id, bytes DESC").Find(&languages).Error
+
+	return languages, err
+}
+
+// Load published releases of repositories tracked for reason, or all if reason is "", newest first
+func LoadReleases(db *gorm.DB, reason string) ([]Github.ReleaseModel, error) {
+	var releases []Github.ReleaseModel
+
+	query := db.Where("published_at IS NOT NULL AND draft = ?", false)
+	if reason != "" {
+		query = query.Where("repository_id IN (?)", db.Model(&Github.RepositoryTrackingModel{}).Select("repository_id").Where("reason = ?", reason))
+	}
+	err := query.Order("published_at DESC").Find(&releases).Error
+
+	return releases, err
+}
+
+// Map of repository IDs to their stored number of contributors
+func LoadContributors(db *gorm.DB) (map[int]int, error) {
+	var models []Github.RepositoryContributorsModel
+	if err := db.Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	contributors := make(map[int]int, len(models))
+	for _, m := range models {
+		contributors[m.RepositoryID] = m.Contributors
+	}
+
+	return contributors, nil
+}
+
+// Map of repository IDs to when an enricher last ran for them
+func LoadEnrichmentStates(db *gorm.DB, enricher string) (map[int]Github.EnrichmentStateModel, error) {
+	var states []Github.EnrichmentStateModel
+	if err := db.Where("enricher = ?", enricher).Find(&states).Error; err != nil {
+		return nil, err
+	}
+
+	byRepo := make(map[int]Github.EnrichmentStateModel, len(states))
+	for _, state := range states {
+		byRepo[state.RepositoryID] = state
+	}
+
+	return byRepo, nil
+}
+
+// Record that an enricher ran for a repository at its current pushed_at
+func SetEnriched(db *gorm.DB, enricher string, repo Github.RepositoryModel) error {
+	state := Github.EnrichmentStateModel{
+		Enricher:     enricher,
+		RepositoryID: repo.ID,
+		PushedAt:     repo.PushedAt,
+		EnrichedAt:   time.Now(),
+	}
+
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&state).Error
+}
+
+// Save a repository's README gzip-compressed, or delete it if content is nil
+func SaveReadme(db *gorm.DB, repoID int, content []byte) error {
+	if content == nil {
+		return db.Where("repository_id = ?", repoID).Delete(&Github.ReadmeModel{}).Error
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(content); err != nil {
+		return fmt.Errorf("error compressing README: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error compressing README: %w", err)
+	}
+
+	readme := Github.ReadmeModel{RepositoryID: repoID, Content: buf.Bytes(), Size: len(content), FetchedAt: time.Now()}
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&readme).Error
+}
+
+// Load a stored repository's README text by its "owner/repo" name, matched case-insensitively, or "" if none is stored.
+// Returns ErrRepositoryNotFound if the repository isn't stored.
+func LoadReadme(db *gorm.DB, fullName string) (string, error) {
+	var repoIDs []int
+	if err := repositoryIDsByName(db, fullName).Pluck("id", &repoIDs).Error; err != nil {
+		return "", err
+	}
+	if len(repoIDs) == 0 {
+		return "", ErrRepositoryNotFound
+	}
+
+	var readme Github.ReadmeModel
+	err := db.Where("repository_id IN ?", repoIDs).Limit(1).Find(&readme).Error
+	if err != nil || readme.Content == nil {
+		return "", err
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(readme.Content))
+	if err != nil {
+		return "", fmt.Errorf("error decompressing README: %w", err)
+	}
+	defer gz.Close()
+
+	content, err := io.ReadAll(gz)
+	if err != nil {
+		return "", fmt.Errorf("error decompressing README: %w", err)
+	}
+
+	return string(content), nil
+}
+
+// Save a repository's number of contributors
+func SaveContributors(db *gorm.DB, repoID int, contributors int) error {
+	model := Github.RepositoryContributorsModel{RepositoryID: repoID, Contributors: contributors}
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&model).Error
+}
+
 // Initialize the database
-func InitDB() (*gorm.DB, error) {
+func InitDB(dsn string) (*gorm.DB, error) {
 	// Create database connection
-	db, err := gorm.Open(sqlite.Open("mygithub.db"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
 	if err != nil {
 		return nil, err
 	}
 
+	// Databases from before tracking reasons only held starred repositories
+	backfillStarred := !db.Migrator().HasTable(&Github.RepositoryTrackingModel{})
+
 	// Do migrations
 	err = db.AutoMigrate(
 		&Github.RepositoryModel{},
 		&Github.RepositoryOwnerModel{},
 		&Github.RepositoryLicenseModel{},
 		&Github.RepositoryPermissionsModel{},
+		&Github.StarListModel{},
+		&Github.StarListItemModel{},
+		&Github.RepositoryTrackingModel{},
+		&Github.FollowModel{},
+		&Github.GistModel{},
+		&Github.GistFileModel{},
+		&Github.NotificationModel{},
+		&Github.ReleaseModel{},
+		&Github.SyncStateModel{},
+		&Github.RepositoryLanguageModel{},
+		&Github.EnrichmentStateModel{},
+		&Github.ReadmeModel{},
+		&Github.RepositoryContributorsModel{},
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if backfillStarred {
+		err = db.Exec(
+			"INSERT INTO repository_tracking_models (repository_id, reason, updated_at) SELECT id, ?, ? FROM repository_models",
+			Github.TrackStarred, time.Now(),
+		).Error
+		if err != nil {
+			return nil, fmt.Errorf("error backfilling tracking reasons: %w", err)
+		}
+	}
+
 	return db, nil
 }
@@ -93,6 +93,7 @@ type RepositoryModel struct {
 	DefaultBranch            string                      `json:"default_branch"`
 	PermissionsID            *int                        `gorm:"index"` // foreign key to RepositoryPermissions.ID
 	Permissions              *RepositoryPermissionsModel `gorm:"foreignKey:PermissionsID;references:ID" json:"permissions"`
+	StarredAt                sql.NullTime                `json:"starred_at"`
 }
 
 // Model for details about the owner of a repository
@@ -137,3 +138,151 @@ type RepositoryPermissionsModel struct {
 	Triage   sql.NullBool `json:"triage"`
 	Pull     sql.NullBool `json:"pull"`
 }
+
+// Model for a user's star list (Github Lists)
+type StarListModel struct {
+	ID          string              `gorm:"primaryKey" json:"id"` // GraphQL node ID
+	Name        string              `gorm:"index" json:"name"`
+	Slug        string              `json:"slug"`
+	Description sql.NullString      `json:"description"`
+	IsPrivate   bool                `json:"is_private"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Items       []StarListItemModel `gorm:"foreignKey:StarListID;references:ID" json:"items"`
+}
+
+// Model for a repository's membership in a star list
+type StarListItemModel struct {
+	StarListID   string           `gorm:"primaryKey" json:"star_list_id"`  // foreign key to StarListModel.ID
+	RepositoryID int              `gorm:"primaryKey" json:"repository_id"` // foreign key to RepositoryModel.ID
+	Repository   *RepositoryModel `gorm:"foreignKey:RepositoryID;references:ID" json:"repository"`
+	FullName     string           `json:"full_name"` // kept for repositories not in the database
+}
+
+// Reasons a repository is tracked in the database
+const (
+	TrackStarred      = "starred"      // starred by the authenticated user
+	TrackOwned        = "owned"        // owned by the authenticated user
+	TrackCollaborator = "collaborator" // the authenticated user is a collaborator
+	TrackOrg          = "org"          // belongs to an organization the user is a member of
+	TrackWatching     = "watching"     // watched (subscribed to) by the authenticated user
+)
+
+// Model for why a repository is tracked, a repository can have several reasons
+type RepositoryTrackingModel struct {
+	RepositoryID int       `gorm:"primaryKey" json:"repository_id"` // foreign key to RepositoryModel.ID
+	Reason       string    `gorm:"primaryKey" json:"reason"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Follow directions, relative to the user whose follows were fetched
+const (
+	Followers = "followers"
+	Following = "following"
+)
+
+// Model for a user in another user's followers or following, as of the last sync
+type FollowModel struct {
+	Login     string                `gorm:"primaryKey" json:"login"`     // lowercase login of the user whose follows were fetched
+	Direction string                `gorm:"primaryKey" json:"direction"` // Followers or Following
+	UserID    int                   `gorm:"primaryKey" json:"user_id"`   // foreign key to RepositoryOwnerModel.ID
+	User      *RepositoryOwnerModel `gorm:"foreignKey:UserID;references:ID" json:"user"`
+	SyncedAt  time.Time             `json:"synced_at"`
+}
+
+// Model for a backed up gist
+type GistModel struct {
+	ID          string          `gorm:"primaryKey" json:"id"`
+	NodeID      string          `json:"node_id"`
+	HTMLURL     string          `json:"html_url"`
+	Description sql.NullString  `json:"description"`
+	Public      bool            `json:"public"`
+	OwnerLogin  string          `json:"owner_login"`
+	Comments    int             `json:"comments"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime:false" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"autoUpdateTime:false" json:"updated_at"` // Github's updated_at, used for incremental backups
+	Files       []GistFileModel `gorm:"foreignKey:GistID;references:ID" json:"files"`
+}
+
+// Model for a file in a backed up gist, with its contents
+type GistFileModel struct {
+	GistID   string         `gorm:"primaryKey" json:"gist_id"` // foreign key to GistModel.ID
+	Filename string         `gorm:"primaryKey" json:"filename"`
+	Type     string         `json:"type"`
+	Language sql.NullString `json:"language"`
+	Size     int            `json:"size"`
+	Content  string         `json:"content"`
+}
+
+// Model for a notification thread, kept for reporting after it's read
+type NotificationModel struct {
+	ID           string         `gorm:"primaryKey" json:"id"`
+	RepositoryID int            `gorm:"index" json:"repository_id"`
+	FullName     string         `gorm:"index" json:"full_name"`
+	SubjectTitle string         `json:"subject_title"`
+	SubjectType  string         `json:"subject_type"`
+	SubjectURL   sql.NullString `json:"subject_url"`
+	Reason       string         `gorm:"index" json:"reason"`
+	Unread       bool           `json:"unread"`
+	UpdatedAt    time.Time      `gorm:"autoUpdateTime:false" json:"updated_at"` // Github's updated_at
+	LastReadAt   sql.NullTime   `json:"last_read_at"`
+	FirstSeenAt  time.Time      `json:"first_seen_at"`
+}
+
+// Model for a repository's release
+type ReleaseModel struct {
+	ID           int            `gorm:"primaryKey" json:"id"`
+	RepositoryID int            `gorm:"index" json:"repository_id"` // foreign key to RepositoryModel.ID
+	NodeID       string         `json:"node_id"`
+	TagName      string         `json:"tag_name"`
+	Name         sql.NullString `json:"name"`
+	Body         sql.NullString `json:"body"`
+	HTMLURL      string         `json:"html_url"`
+	Draft        bool           `json:"draft"`
+	Prerelease   bool           `json:"prerelease"`
+	CreatedAt    time.Time      `gorm:"autoCreateTime:false" json:"created_at"`
+	PublishedAt  sql.NullTime   `gorm:"index" json:"published_at"`
+}
+
+// Model for when a command last ran, for reporting what's new since then
+type SyncStateModel struct {
+	Name      string    `gorm:"primaryKey" json:"name"`
+	LastRunAt time.Time `json:"last_run_at"`
+}
+
+// Model for the bytes of code in one language of a repository
+type RepositoryLanguageModel struct {
+	RepositoryID int    `gorm:"primaryKey" json:"repository_id"` // foreign key to RepositoryModel.ID
+	Language     string `gorm:"primaryKey;index" json:"language"`
+	Bytes        int    `json:"bytes"`
+}
+
+// Enrichers, names for EnrichmentStateModel.Enricher
+const (
+	EnrichLanguages    = "languages"    // per-language byte counts
+	EnrichReleases     = "releases"     // recent releases
+	EnrichReadme       = "readme"       // README text
+	EnrichContributors = "contributors" // number of contributors
+)
+
+// Model for when a repository was last enriched with extra API data, for incremental runs
+type EnrichmentStateModel struct {
+	Enricher     string    `gorm:"primaryKey" json:"enricher"`
+	RepositoryID int       `gorm:"primaryKey" json:"repository_id"` // foreign key to RepositoryModel.ID
+	PushedAt     time.Time `json:"pushed_at"`                       // repository's pushed_at when enriched, re-enriched when it changes
+	EnrichedAt   time.Time `json:"enriched_at"`
+}
+
+// Model for a repository's README, gzip-compressed
+type ReadmeModel struct {
+	RepositoryID int       `gorm:"primaryKey" json:"repository_id"` // foreign key to RepositoryModel.ID
+	Content      []byte    `json:"-"`                               // gzip-compressed README text
+	Size         int       `json:"size"`                            // uncompressed size in bytes
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// Model for a repository's number of contributors
+type RepositoryContributorsModel struct {
+	RepositoryID int `gorm:"primaryKey" json:"repository_id"` // foreign key to RepositoryModel.ID
+	Contributors int `json:"contributors"`
+}
@@ -85,6 +85,31 @@ type Repository struct {
 	Watchers                 int                    `json:"watchers"`
 	DefaultBranch            string                 `json:"default_branch"`
 	Permissions              *RepositoryPermissions `json:"permissions"`
+	// Not part of the REST repository object, set when fetching stars
+	StarredAt *time.Time `json:"starred_at,omitempty"`
+	// Only set when fetching with the GraphQL API
+	LatestRelease *Release       `json:"latest_release,omitempty"`
+	Languages     map[string]int `json:"languages,omitempty"`
+}
+
+// Schema for a starred repository from the "application/vnd.github.star+json" media type
+type StarredRepository struct {
+	StarredAt time.Time  `json:"starred_at"`
+	Repo      Repository `json:"repo"`
+}
+
+// Schema for a repository release
+type Release struct {
+	ID          int        `json:"id"`
+	NodeID      string     `json:"node_id"`
+	TagName     string     `json:"tag_name"`
+	Name        *string    `json:"name"`
+	Body        *string    `json:"body"`
+	HTMLURL     string     `json:"html_url"`
+	Draft       bool       `json:"draft"`
+	Prerelease  bool       `json:"prerelease"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at"`
 }
 
 // Schema for the owner of a repository
@@ -127,3 +152,107 @@ type RepositoryPermissions struct {
 	Triage   *bool `json:"triage"`
 	Pull     *bool `json:"pull"`
 }
+
+// Schema for a Github user, i.e. the authenticated user from /user
+type User struct {
+	RepositoryOwner
+	Name        *string   `json:"name"`
+	Company     *string   `json:"company"`
+	Blog        *string   `json:"blog"`
+	Location    *string   `json:"location"`
+	Email       *string   `json:"email"`
+	Bio         *string   `json:"bio"`
+	PublicRepos int       `json:"public_repos"`
+	PublicGists int       `json:"public_gists"`
+	Followers   int       `json:"followers"`
+	Following   int       `json:"following"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Schema for a single rate limit bucket
+type RateLimit struct {
+	Limit     int   `json:"limit"`
+	Used      int   `json:"used"`
+	Remaining int   `json:"remaining"`
+	Reset     int64 `json:"reset"`
+}
+
+// Schema for the /rate_limit response
+type RateLimits struct {
+	Resources RateLimitResources `json:"resources"`
+}
+
+// Rate limit buckets by API
+type RateLimitResources struct {
+	Core    RateLimit `json:"core"`
+	Search  RateLimit `json:"search"`
+	GraphQL RateLimit `json:"graphql"`
+}
+
+// Schema for a user's star list (Github Lists), from the GraphQL API
+type StarList struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	Slug        string         `json:"slug"`
+	Description *string        `json:"description"`
+	IsPrivate   bool           `json:"is_private"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	Items       []StarListItem `json:"items"`
+}
+
+// Schema for a repository in a star list
+type StarListItem struct {
+	RepositoryID int    `json:"repository_id"`
+	FullName     string `json:"full_name"`
+}
+
+// Schema for a gist from /gists or /gists/{id}
+type Gist struct {
+	ID          string              `json:"id"`
+	NodeID      string              `json:"node_id"`
+	URL         string              `json:"url"`
+	HTMLURL     string              `json:"html_url"`
+	GitPullURL  string              `json:"git_pull_url"`
+	Description *string             `json:"description"`
+	Public      bool                `json:"public"`
+	Owner       *RepositoryOwner    `json:"owner"`
+	Files       map[string]GistFile `json:"files"`
+	Comments    int                 `json:"comments"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Truncated   bool                `json:"truncated"`
+}
+
+// Schema for a file in a gist. Content is only set when fetching a single gist.
+type GistFile struct {
+	Filename  string  `json:"filename"`
+	Type      string  `json:"type"`
+	Language  *string `json:"language"`
+	RawURL    string  `json:"raw_url"`
+	Size      int     `json:"size"`
+	Truncated bool    `json:"truncated,omitempty"`
+	Content   string  `json:"content,omitempty"`
+}
+
+// Schema for a notification thread from /notifications
+type Notification struct {
+	ID              string              `json:"id"`
+	Repository      Repository          `json:"repository"`
+	Subject         NotificationSubject `json:"subject"`
+	Reason          string              `json:"reason"`
+	Unread          bool                `json:"unread"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	LastReadAt      *time.Time          `json:"last_read_at"`
+	URL             string              `json:"url"`
+	SubscriptionURL string              `json:"subscription_url"`
+}
+
+// Schema for the issue, pull request, release, etc. a notification is about
+type NotificationSubject struct {
+	Title            string  `json:"title"`
+	URL              *string `json:"url"`
+	LatestCommentURL *string `json:"latest_comment_url"`
+	Type             string  `json:"type"`
+}
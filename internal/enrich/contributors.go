@@ -0,0 +1,24 @@
+package enrich
+
+import (
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"gorm.io/gorm"
+)
+
+// A repository's number of contributors
+type Contributors struct{}
+
+func (Contributors) Name() string { return Github.EnrichContributors }
+
+func (Contributors) Enrich(client *ghclient.Client, repo Github.RepositoryModel) (Save, error) {
+	count, err := client.GetContributorCount(db.ConvertModelToRepository(repo))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tx *gorm.DB) error {
+		return db.SaveContributors(tx, repo.ID, count)
+	}, nil
+}
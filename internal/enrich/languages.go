@@ -0,0 +1,24 @@
+package enrich
+
+import (
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"gorm.io/gorm"
+)
+
+// Bytes of code per language, from a repository's languages_url
+type Languages struct{}
+
+func (Languages) Name() string { return Github.EnrichLanguages }
+
+func (Languages) Enrich(client *ghclient.Client, repo Github.RepositoryModel) (Save, error) {
+	languages, err := client.GetLanguages(db.ConvertModelToRepository(repo))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tx *gorm.DB) error {
+		return db.SaveLanguages(tx, repo.ID, languages)
+	}, nil
+}
@@ -0,0 +1,111 @@
+package enrich
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/ghclient"
+)
+
+// Returned instead of making a request once the rate limit reserve is reached
+type reserveError struct {
+	remaining int
+	reserve   int
+	reset     time.Time
+}
+
+func (e *reserveError) Error() string {
+	return fmt.Sprintf("stopped with %d API requests remaining to keep %d in reserve, the limit resets at %s", e.remaining, e.reserve, e.reset.Local().Format(time.Kitchen))
+}
+
+// Handled like running out of requests
+func (e *reserveError) Unwrap() error {
+	return ghclient.ErrRateLimited
+}
+
+// HTTP transport that spaces out requests from all workers & stops near the rate limit
+type rateLimiter struct {
+	base     http.RoundTripper
+	interval time.Duration
+	reserve  int
+
+	mu        sync.Mutex
+	next      time.Time // earliest start of the next request
+	remaining int       // from the last response, -1 until known
+	reset     time.Time
+}
+
+// Wrap an HTTP client's transport, i.e. the HTTP cache, in a rate limiter
+func newRateLimiter(httpClient *http.Client, interval time.Duration, reserve int) *rateLimiter {
+	base := http.DefaultTransport
+	if httpClient != nil && httpClient.Transport != nil {
+		base = httpClient.Transport
+	}
+
+	return &rateLimiter{base: base, interval: interval, reserve: reserve, remaining: -1}
+}
+
+// HTTP client using the rate limiter
+func (l *rateLimiter) Client() *http.Client {
+	return &http.Client{Transport: l}
+}
+
+// Wait for a turn, then make the request & record the remaining rate limit
+func (l *rateLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := l.wait(); err != nil {
+		return nil, err
+	}
+
+	resp, err := l.base.RoundTrip(req)
+	if err == nil {
+		l.record(resp)
+	}
+
+	return resp, err
+}
+
+// Sleep until the next request slot, or fail if the reserve is reached
+func (l *rateLimiter) wait() error {
+	l.mu.Lock()
+	if l.reserve > 0 && l.remaining >= 0 && l.remaining < l.reserve && time.Now().Before(l.reset) {
+		err := &reserveError{remaining: l.remaining, reserve: l.reserve, reset: l.reset}
+		l.mu.Unlock()
+		return err
+	}
+
+	start := time.Now()
+	if l.next.After(start) {
+		start = l.next
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	time.Sleep(time.Until(start))
+
+	return nil
+}
+
+// Track the remaining requests from a response's rate limit headers
+func (l *rateLimiter) record(resp *http.Response) {
+	// Responses served from the HTTP cache carry old headers
+	if resp.Header.Get("X-From-Cache") != "" {
+		return
+	}
+
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remaining = remaining
+	l.reset = time.Unix(reset, 0)
+}
@@ -0,0 +1,112 @@
+package enrich
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"gorm.io/gorm"
+)
+
+// A repository's README, gzip-compressed in the database or, if Dir is set, on disk
+type Readme struct {
+	Dir string
+}
+
+func (*Readme) Name() string { return Github.EnrichReadme }
+
+func (r *Readme) Enrich(client *ghclient.Client, repo Github.RepositoryModel) (Save, error) {
+	content, err := client.GetReadme(db.ConvertModelToRepository(repo))
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tx *gorm.DB) error {
+		if r.Dir != "" {
+			return writeReadmeFile(r.Dir, repo.FullName, content)
+		}
+		return db.SaveReadme(tx, repo.ID, content)
+	}, nil
+}
+
+// Path of a repository's README in a directory, as <dir>/<owner>/<repo>.md.gz
+func ReadmePath(dir string, fullName string) (string, error) {
+	// Names come from the API, but make sure they can't escape dir
+	owner, name, ok := strings.Cut(fullName, "/")
+	for _, part := range []string{owner, name} {
+		if !ok || part == "" || part != filepath.Base(part) || part == "." || part == ".." {
+			return "", fmt.Errorf("invalid repository name %q (expected owner/repo)", fullName)
+		}
+	}
+
+	return filepath.Join(dir, owner, name+".md.gz"), nil
+}
+
+// Read a README stored in a directory, or an os.ErrNotExist error if there isn't one
+func ReadReadmeFile(dir string, fullName string) (string, error) {
+	path, err := ReadmePath(dir, fullName)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("error decompressing %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	content, err := io.ReadAll(gz)
+	if err != nil {
+		return "", fmt.Errorf("error decompressing %s: %w", path, err)
+	}
+
+	return string(content), nil
+}
+
+// Write a README gzip-compressed to a directory, or remove it if content is nil
+func writeReadmeFile(dir string, fullName string, content []byte) error {
+	path, err := ReadmePath(dir, fullName)
+	if err != nil {
+		return err
+	}
+
+	if content == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing %s: %w", path, err)
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(content); err != nil {
+		return fmt.Errorf("error compressing README: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error compressing README: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	return nil
+}
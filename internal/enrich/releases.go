@@ -0,0 +1,37 @@
+package enrich
+
+import (
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"gorm.io/gorm"
+)
+
+// Number of recent releases stored per repository, enough to tell how often it releases
+const recentReleases = 10
+
+// A repository's most recent releases
+type Releases struct{}
+
+func (Releases) Name() string { return Github.EnrichReleases }
+
+// Releases can be published from an existing tag without a push
+func (Releases) MaxAge() time.Duration { return 24 * time.Hour }
+
+func (Releases) Enrich(client *ghclient.Client, repo Github.RepositoryModel) (Save, error) {
+	releases, err := client.GetReleases(db.ConvertModelToRepository(repo), recentReleases)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(tx *gorm.DB) error {
+		for _, release := range releases {
+			if err := db.SaveRelease(tx, repo.ID, release); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,258 @@
+/* Enrich stored repositories with data from per-repository API endpoints. */
+package enrich
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/db"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+	"github.com/redjax/go-mygithub/internal/ghclient"
+	"gorm.io/gorm"
+)
+
+// Fetches extra data for one repository at a time
+type Enricher interface {
+	// Name used with --only & in the enrichment state table
+	Name() string
+	// Fetch a repository's data & return a function that stores it.
+	// Enrich is called from several workers at once, Save functions one at a time.
+	Enrich(client *ghclient.Client, repo Github.RepositoryModel) (Save, error)
+}
+
+// Stores fetched data, in the runner's database transaction
+type Save func(tx *gorm.DB) error
+
+// Optional for enrichers whose data changes without a push, i.e. releases.
+// Repositories are enriched again once their data is older than MaxAge.
+type MaxAger interface {
+	MaxAge() time.Duration
+}
+
+// Default number of concurrent workers
+const DefaultWorkers = 4
+
+// Default number of API requests to leave unused
+const DefaultReserve = 100
+
+// Every enricher, in the order they run
+func All() []Enricher {
+	return []Enricher{Languages{}, Releases{}, &Readme{}, Contributors{}}
+}
+
+// Names of every enricher
+func Names() []string {
+	var names []string
+	for _, e := range All() {
+		names = append(names, e.Name())
+	}
+
+	return names
+}
+
+// Enrichers by name, or all of them if names is empty
+func Select(names []string) ([]Enricher, error) {
+	if len(names) == 0 {
+		return All(), nil
+	}
+
+	var selected []Enricher
+	for _, e := range All() {
+		if slices.Contains(names, e.Name()) {
+			selected = append(selected, e)
+		}
+	}
+	for _, name := range names {
+		if !slices.Contains(Names(), name) {
+			return nil, fmt.Errorf("unknown enricher %q (expected %s)", name, strings.Join(Names(), ", "))
+		}
+	}
+
+	return selected, nil
+}
+
+// Options for an enrichment run
+type Options struct {
+	Workers      int
+	Force        bool          // enrich every repository, even if fresh
+	Limit        int           // repositories per enricher, 0 for all
+	RequestSleep time.Duration // minimum time between requests, across all workers
+	Reserve      int           // stop when fewer API requests than this remain
+}
+
+// Outcome of one enricher's run
+type Result struct {
+	Enricher  string
+	Enriched  int
+	UpToDate  int
+	Failed    int
+	Remaining int // stale repositories left for the next run
+}
+
+// One repository for one enricher
+type job struct {
+	index int // into the enrichers & results
+	repo  Github.RepositoryModel
+}
+
+// Result of a job, stored by the collecting goroutine
+type outcome struct {
+	job
+	save Save
+	err  error
+}
+
+// Run enrichers over repositories with a pool of workers sharing one rate limit.
+// Results are saved as they arrive, so a run that stops early resumes where it left off.
+func Run(client *ghclient.Client, dbConn *gorm.DB, repos []Github.RepositoryModel, enrichers []Enricher, opts Options) ([]Result, error) {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+
+	// Find stale repositories for each enricher
+	results := make([]Result, len(enrichers))
+	var jobs []job
+	now := time.Now()
+	for i, e := range enrichers {
+		states, err := db.LoadEnrichmentStates(dbConn, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error loading enrichment state: %w", err)
+		}
+
+		results[i].Enricher = e.Name()
+		queued := 0
+		for _, repo := range repos {
+			state, ok := states[repo.ID]
+			if !opts.Force && ok && isFresh(e, repo, state, now) {
+				results[i].UpToDate++
+				continue
+			}
+			if opts.Limit > 0 && queued >= opts.Limit {
+				results[i].Remaining++
+				continue
+			}
+			jobs = append(jobs, job{index: i, repo: repo})
+			queued++
+		}
+	}
+	if len(jobs) == 0 {
+		return results, nil
+	}
+
+	// Every worker's requests go through the same limiter
+	limited := *client
+	limited.HTTP = newRateLimiter(client.HTTP, opts.RequestSleep, opts.Reserve).Client()
+
+	// Stopped on rate limits & database errors
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	halt := func() { stopOnce.Do(func() { close(stop) }) }
+
+	queue := make(chan job)
+	go func() {
+		defer close(queue)
+		for _, j := range jobs {
+			select {
+			case queue <- j:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	outcomes := make(chan outcome)
+	var wg sync.WaitGroup
+	for range opts.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range queue {
+				select {
+				case <-stop:
+					continue
+				default:
+				}
+				save, err := enrichers[j.index].Enrich(&limited, j.repo)
+				outcomes <- outcome{job: j, save: save, err: err}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	// Save results one at a time, SQLite allows a single writer
+	var runErr error
+	done := 0
+	for o := range outcomes {
+		e := enrichers[o.index]
+		done++
+
+		if o.err != nil {
+			if errors.Is(o.err, ghclient.ErrRateLimited) {
+				if runErr == nil {
+					runErr = o.err
+				}
+				halt()
+				continue
+			}
+			fmt.Fprintf(os.Stderr, "  %s: %s: %v\n", e.Name(), o.repo.FullName, o.err)
+			results[o.index].Failed++
+			continue
+		}
+		if runErr != nil {
+			continue
+		}
+
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			if o.save != nil {
+				if err := o.save(tx); err != nil {
+					return err
+				}
+			}
+			return db.SetEnriched(tx, e.Name(), o.repo)
+		})
+		if err != nil {
+			runErr = fmt.Errorf("error saving %s for %s: %w", e.Name(), o.repo.FullName, err)
+			halt()
+			continue
+		}
+		results[o.index].Enriched++
+
+		// Log every 50 repos
+		if done%50 == 0 || done == len(jobs) {
+			fmt.Fprintf(os.Stderr, "  Enriched %d/%d...\n", done, len(jobs))
+		}
+	}
+
+	// Jobs not finished are left for the next run
+	for i := range results {
+		queued := 0
+		for _, j := range jobs {
+			if j.index == i {
+				queued++
+			}
+		}
+		results[i].Remaining += queued - results[i].Enriched - results[i].Failed
+	}
+
+	return results, runErr
+}
+
+// Whether a repository's enriched data is still current
+func isFresh(e Enricher, repo Github.RepositoryModel, state Github.EnrichmentStateModel, now time.Time) bool {
+	if !state.PushedAt.Equal(repo.PushedAt) {
+		return false
+	}
+	if m, ok := e.(MaxAger); ok && now.Sub(state.EnrichedAt) > m.MaxAge() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,304 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Feed formats
+const (
+	FormatAtom = "atom"
+	FormatRSS  = "rss"
+)
+
+// Prefix for stable entry IDs, in the same tag: scheme Github uses for its own feeds
+const idPrefix = "tag:github.com,2008:mygithub"
+
+// A feed of new releases & newly starred repositories
+type Feed struct {
+	ID          string
+	Title       string
+	Link        string
+	Description string
+	Updated     time.Time // newest entry, so regenerating from the same data gives the same feed
+	Entries     []Entry
+}
+
+// One feed entry
+type Entry struct {
+	ID        string // never changes for the same release or star
+	Title     string
+	Link      string
+	Author    string
+	Summary   string
+	Published time.Time
+}
+
+// Build a feed from stored releases & starred repositories, newest first, up to limit entries
+func Build(title string, link string, releases []Github.ReleaseModel, repoNames map[int]string, starred []Github.RepositoryModel, limit int) Feed {
+	f := Feed{
+		ID:          idPrefix + "/feed",
+		Title:       title,
+		Link:        link,
+		Description: "New releases & newly starred repositories",
+	}
+
+	for _, r := range releases {
+		// Releases of repositories no longer stored are skipped
+		name, ok := repoNames[r.RepositoryID]
+		if !ok || !r.PublishedAt.Valid {
+			continue
+		}
+		f.Entries = append(f.Entries, releaseEntry(r, name))
+	}
+	for _, repo := range starred {
+		if !repo.StarredAt.Valid {
+			continue
+		}
+		f.Entries = append(f.Entries, starEntry(repo))
+	}
+
+	// Ties are broken by ID so the order is stable
+	sort.Slice(f.Entries, func(i, j int) bool {
+		a, b := f.Entries[i], f.Entries[j]
+		if !a.Published.Equal(b.Published) {
+			return a.Published.After(b.Published)
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(f.Entries) > limit {
+		f.Entries = f.Entries[:limit]
+	}
+
+	if len(f.Entries) > 0 {
+		f.Updated = f.Entries[0].Published
+	} else {
+		f.Updated = time.Unix(0, 0)
+	}
+
+	return f
+}
+
+// Entry for a published release
+func releaseEntry(r Github.ReleaseModel, fullName string) Entry {
+	title := fmt.Sprintf("%s %s", fullName, r.TagName)
+	if r.Name.Valid && strings.TrimSpace(r.Name.String) != "" && r.Name.String != r.TagName {
+		title = fmt.Sprintf("%s %s (%s)", fullName, strings.TrimSpace(r.Name.String), r.TagName)
+	}
+	if r.Prerelease {
+		title += " [pre-release]"
+	}
+
+	return Entry{
+		ID:        fmt.Sprintf("%s/Release/%d", idPrefix, r.ID),
+		Title:     title,
+		Link:      r.HTMLURL,
+		Author:    repoOwner(fullName),
+		Summary:   strings.TrimSpace(r.Body.String),
+		Published: r.PublishedAt.Time.UTC(),
+	}
+}
+
+// Entry for a newly starred repository
+func starEntry(repo Github.RepositoryModel) Entry {
+	summary := strings.TrimSpace(repo.Description.String)
+	if repo.Language.Valid && repo.Language.String != "" {
+		summary = strings.TrimSpace(fmt.Sprintf("%s\n\nLanguage: %s", summary, repo.Language.String))
+	}
+
+	link := repo.HTMLURL
+	if link == "" {
+		link = "https://github.com/" + repo.FullName
+	}
+
+	// Starring again after unstarring gets a new entry
+	return Entry{
+		ID:        fmt.Sprintf("%s/Star/%d/%d", idPrefix, repo.ID, repo.StarredAt.Time.Unix()),
+		Title:     "Starred " + repo.FullName,
+		Link:      link,
+		Author:    repoOwner(repo.FullName),
+		Summary:   summary,
+		Published: repo.StarredAt.Time.UTC(),
+	}
+}
+
+// Owner login from an owner/repo name
+func repoOwner(fullName string) string {
+	owner, _, _ := strings.Cut(fullName, "/")
+	return owner
+}
+
+// Atom 1.0 document
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Links   []atomLink  `xml:"link"`
+	Updated string      `xml:"updated"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Link      atomLink    `xml:"link"`
+	Published string      `xml:"published"`
+	Updated   string      `xml:"updated"`
+	Author    *atomAuthor `xml:"author,omitempty"`
+	Summary   *atomText   `xml:"summary,omitempty"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// Write the feed as Atom
+func (f Feed) WriteAtom(w io.Writer) error {
+	doc := atomFeed{
+		ID:      f.ID,
+		Title:   f.Title,
+		Links:   []atomLink{{Href: f.Link, Rel: "alternate"}},
+		Updated: f.Updated.UTC().Format(time.RFC3339),
+		Author:  atomAuthor{Name: "mygithub"},
+	}
+
+	for _, e := range f.Entries {
+		entry := atomEntry{
+			ID:        e.ID,
+			Title:     e.Title,
+			Link:      atomLink{Href: e.Link, Rel: "alternate"},
+			Published: e.Published.Format(time.RFC3339),
+			Updated:   e.Published.Format(time.RFC3339),
+		}
+		if e.Author != "" {
+			entry.Author = &atomAuthor{Name: e.Author}
+		}
+		if e.Summary != "" {
+			entry.Summary = &atomText{Type: "text", Body: e.Summary}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return writeXML(w, doc)
+}
+
+// RSS 2.0 document
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Description string  `xml:"description,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// Write the feed as RSS
+func (f Feed) WriteRSS(w io.Writer) error {
+	doc := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: f.Updated.UTC().Format(time.RFC1123Z),
+		},
+	}
+
+	for _, e := range f.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       e.Title,
+			Link:        e.Link,
+			GUID:        rssGUID{IsPermaLink: false, Value: e.ID},
+			PubDate:     e.Published.Format(time.RFC1123Z),
+			Description: e.Summary,
+		})
+	}
+
+	return writeXML(w, doc)
+}
+
+// Write an XML document with a header & indentation
+func writeXML(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("error formatting feed: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// Entry IDs in an existing Atom or RSS feed file, or none if it doesn't exist or can't be read
+func ReadEntryIDs(path string) map[string]bool {
+	ids := map[string]bool{}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ids
+	}
+
+	// Matches both Atom entries & RSS items, whichever the file holds
+	var doc struct {
+		Entries []struct {
+			ID string `xml:"id"`
+		} `xml:"entry"`
+		Channel struct {
+			Items []struct {
+				GUID string `xml:"guid"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(raw, &doc); err != nil {
+		return ids
+	}
+
+	for _, e := range doc.Entries {
+		ids[e.ID] = true
+	}
+	for _, item := range doc.Channel.Items {
+		ids[item.GUID] = true
+	}
+
+	return ids
+}
@@ -0,0 +1,73 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Count a repository's contributors, from the last page number of a one-per-page listing
+func (c *Client) GetContributorCount(repo Github.Repository) (int, error) {
+	req, err := c.NewRequest("GET", constants.GH_REPOS_ENDPOINT+"/"+repo.FullName+"/contributors?per_page=1", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Empty repositories have no contributors
+	if resp.StatusCode == http.StatusNoContent {
+		return 0, nil
+	}
+
+	if last := ParseLastPage(resp.Header.Get("Link")); last > 0 {
+		return last, nil
+	}
+
+	// Only one page, count what's on it
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("error reading response body: %v", err)
+	}
+	var contributors []Github.RepositoryOwner
+	if err := json.Unmarshal(bodyBytes, &contributors); err != nil {
+		return 0, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return len(contributors), nil
+}
+
+// Extract the page number of rel="last" from a Link header, or 0 if there isn't one
+func ParseLastPage(linkHeader string) int {
+	for _, p := range strings.Split(linkHeader, ",") {
+		if !strings.Contains(p, `rel="last"`) {
+			continue
+		}
+
+		start := strings.Index(p, "<")
+		end := strings.Index(p, ">")
+		if start == -1 || end <= start {
+			return 0
+		}
+		u, err := url.Parse(p[start+1 : end])
+		if err != nil {
+			return 0
+		}
+		page, _ := strconv.Atoi(u.Query().Get("page"))
+
+		return page
+	}
+
+	return 0
+}
@@ -0,0 +1,134 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for common Github API failures, use with errors.Is
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Error response from the Github API
+type APIError struct {
+	StatusCode       int
+	Method           string
+	URL              string
+	Message          string
+	DocumentationURL string
+	// Scopes the token has (X-OAuth-Scopes)
+	Scopes []string
+	// Scopes the endpoint accepts (X-Accepted-OAuth-Scopes)
+	AcceptedScopes []string
+	// When the rate limit resets, if rate limited
+	RateLimitReset time.Time
+}
+
+// Map the status code to a sentinel error
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == 401:
+		return ErrUnauthorized
+	case e.isRateLimit():
+		return ErrRateLimited
+	case e.StatusCode == 403:
+		return ErrForbidden
+	case e.StatusCode == 404:
+		return ErrNotFound
+	default:
+		return nil
+	}
+}
+
+// Describe the error & how to fix it
+func (e *APIError) Error() string {
+	detail := fmt.Sprintf("%d", e.StatusCode)
+	if e.Message != "" {
+		detail = fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	}
+
+	switch {
+	case e.StatusCode == 401:
+		return fmt.Sprintf("Github rejected the access token (%s): the token is invalid, expired, or revoked. Create a new token or run 'mygithub auth login'", detail)
+	case e.isRateLimit():
+		return fmt.Sprintf("rate limited by Github API (%s), reset at %s (in %s)", detail, e.RateLimitReset.Local().Format(time.Kitchen), time.Until(e.RateLimitReset).Round(time.Second))
+	case e.StatusCode == 403:
+		msg := fmt.Sprintf("access denied to %s %s (%s): the token lacks permission", e.Method, e.URL, detail)
+		if len(e.AcceptedScopes) > 0 {
+			msg += fmt.Sprintf(". Required scopes: %s; token has: %s", strings.Join(e.AcceptedScopes, ", "), scopeList(e.Scopes))
+		}
+		return msg
+	case e.StatusCode == 404:
+		return fmt.Sprintf("not found: %s %s (%s): the resource doesn't exist or the token can't see it. Private resources need the 'repo' scope, or repository access for fine-grained tokens", e.Method, e.URL, detail)
+	default:
+		return fmt.Sprintf("unexpected status from %s %s: %s", e.Method, e.URL, detail)
+	}
+}
+
+// Github returns 403 or 429 with no remaining requests when rate limited
+func (e *APIError) isRateLimit() bool {
+	return (e.StatusCode == 403 || e.StatusCode == 429) && !e.RateLimitReset.IsZero()
+}
+
+// Return an *APIError for error responses, closing the response body
+func CheckResponse(resp *http.Response) error {
+	if resp.StatusCode < 400 {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	apiErr := &APIError{
+		StatusCode:     resp.StatusCode,
+		Method:         resp.Request.Method,
+		URL:            resp.Request.URL.String(),
+		Scopes:         ParseScopes(resp.Header),
+		AcceptedScopes: parseScopeHeader(resp.Header.Get("X-Accepted-OAuth-Scopes")),
+	}
+
+	// Error body is best effort
+	var body struct {
+		Message          string `json:"message"`
+		DocumentationURL string `json:"documentation_url"`
+	}
+	if bodyBytes, err := io.ReadAll(resp.Body); err == nil {
+		json.Unmarshal(bodyBytes, &body)
+	}
+	apiErr.Message = body.Message
+	apiErr.DocumentationURL = body.DocumentationURL
+
+	// Primary rate limit: no requests remaining until reset
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			apiErr.RateLimitReset = time.Unix(reset, 0)
+		}
+	}
+	// Secondary rate limit: wait Retry-After seconds
+	if retry, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RateLimitReset = time.Now().Add(time.Duration(retry) * time.Second)
+	}
+	// Secondary rate limit without Retry-After: Github recommends waiting at least a minute
+	if apiErr.RateLimitReset.IsZero() && strings.Contains(strings.ToLower(apiErr.Message), "rate limit") {
+		apiErr.RateLimitReset = time.Now().Add(time.Minute)
+	}
+
+	return apiErr
+}
+
+// Format a list of scopes for display
+func scopeList(scopes []string) string {
+	if len(scopes) == 0 {
+		return "(none)"
+	}
+
+	return strings.Join(scopes, ", ")
+}
@@ -0,0 +1,43 @@
+package ghclient
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Get a gist with its files' contents
+func (c *Client) GetGist(id string) (*Github.Gist, error) {
+	var gist Github.Gist
+	if _, err := c.GetJSON(constants.GH_GISTS_ENDPOINT+"/"+url.PathEscape(id), &gist); err != nil {
+		return nil, err
+	}
+
+	return &gist, nil
+}
+
+// Download a gist file's full contents from its raw URL.
+// The API truncates file contents over 1MB.
+func (c *Client) GetGistFileContent(file Github.GistFile) (string, error) {
+	// Raw URLs are on another host & don't take the API token
+	resp, err := c.HTTP.Get(file.RawURL)
+	if err != nil {
+		return "", fmt.Errorf("error downloading %s: %v", file.Filename, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error downloading %s: unexpected status %d", file.Filename, resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", file.Filename, err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,91 @@
+package ghclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Error returned in a GraphQL response body
+type GraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	// Field names & list indexes leading to the error, i.e. ["viewer", "lists", 0]
+	Path []any `json:"path"`
+}
+
+// One or more errors returned by a GraphQL query
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Message
+		if err.Type != "" {
+			msgs[i] = fmt.Sprintf("%s: %s", err.Type, err.Message)
+		}
+		if len(err.Path) > 0 {
+			msgs[i] += fmt.Sprintf(" (at %s)", graphQLPath(err.Path))
+		}
+	}
+
+	return "graphql: " + strings.Join(msgs, "; ")
+}
+
+// Join a GraphQL error path, i.e. viewer.lists.0.name
+func graphQLPath(path []any) string {
+	parts := make([]string, len(path))
+	for i, p := range path {
+		parts[i] = fmt.Sprint(p)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// Run a GraphQL query & unmarshal the response's "data" into out
+func (c *Client) GraphQL(query string, variables map[string]any, out any) error {
+	payload, err := json.Marshal(map[string]any{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding GraphQL query: %w", err)
+	}
+
+	req, err := c.NewRequest("POST", c.GraphQLURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %v", err)
+	}
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}
+	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		return fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return result.Errors
+	}
+
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("error unmarshaling GraphQL data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package ghclient
+
+import (
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Get a repository's languages, as bytes of code per language
+func (c *Client) GetLanguages(repo Github.Repository) (map[string]int, error) {
+	endpoint := repo.LanguagesURL
+	if endpoint == "" {
+		endpoint = constants.GH_REPOS_ENDPOINT + "/" + repo.FullName + "/languages"
+	}
+
+	languages := map[string]int{}
+	if _, err := c.GetJSON(endpoint, &languages); err != nil {
+		return nil, err
+	}
+
+	return languages, nil
+}
@@ -0,0 +1,153 @@
+package ghclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Star lists are only available from the GraphQL API
+const starListsQuery = `
+query($cursor: String) {
+  viewer {
+    lists(first: 50, after: $cursor) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        id
+        name
+        slug
+        description
+        isPrivate
+        createdAt
+        updatedAt
+        items(first: 100) {
+          pageInfo { hasNextPage endCursor }
+          nodes { ... on Repository { databaseId nameWithOwner } }
+        }
+      }
+    }
+  }
+}`
+
+// Fetch further items of a list with more than 100 repositories
+const starListItemsQuery = `
+query($id: ID!, $cursor: String) {
+  node(id: $id) {
+    ... on UserList {
+      items(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes { ... on Repository { databaseId nameWithOwner } }
+      }
+    }
+  }
+}`
+
+// GraphQL cursor pagination info
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GraphQL connection of repositories in a list
+type starListItems struct {
+	PageInfo pageInfo `json:"pageInfo"`
+	Nodes    []struct {
+		DatabaseID    int    `json:"databaseId"`
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"nodes"`
+}
+
+// GraphQL UserList node
+type starListNode struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description *string       `json:"description"`
+	IsPrivate   bool          `json:"isPrivate"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	Items       starListItems `json:"items"`
+}
+
+// Fetch all of the authenticated user's star lists & their repositories
+func (c *Client) FetchStarLists() ([]Github.StarList, error) {
+	var lists []Github.StarList
+	var cursor *string
+
+	for {
+		var data struct {
+			Viewer struct {
+				Lists struct {
+					PageInfo pageInfo       `json:"pageInfo"`
+					Nodes    []starListNode `json:"nodes"`
+				} `json:"lists"`
+			} `json:"viewer"`
+		}
+		if err := c.GraphQL(starListsQuery, map[string]any{"cursor": cursor}, &data); err != nil {
+			return nil, err
+		}
+
+		for _, node := range data.Viewer.Lists.Nodes {
+			list := Github.StarList{
+				ID:          node.ID,
+				Name:        node.Name,
+				Slug:        node.Slug,
+				Description: node.Description,
+				IsPrivate:   node.IsPrivate,
+				CreatedAt:   node.CreatedAt,
+				UpdatedAt:   node.UpdatedAt,
+			}
+
+			items, err := c.fetchRemainingListItems(node.ID, node.Items)
+			if err != nil {
+				return nil, fmt.Errorf("list %q: %w", node.Name, err)
+			}
+			list.Items = items
+
+			lists = append(lists, list)
+		}
+
+		// Check for next page
+		if !data.Viewer.Lists.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &data.Viewer.Lists.PageInfo.EndCursor
+	}
+
+	return lists, nil
+}
+
+// Collect a list's first page of items & fetch any further pages
+func (c *Client) fetchRemainingListItems(listID string, first starListItems) ([]Github.StarListItem, error) {
+	var items []Github.StarListItem
+	page := first
+
+	for {
+		for _, node := range page.Nodes {
+			// Non-repository items have no database ID
+			if node.DatabaseID == 0 {
+				continue
+			}
+			items = append(items, Github.StarListItem{RepositoryID: node.DatabaseID, FullName: node.NameWithOwner})
+		}
+
+		// Check for next page
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+
+		var data struct {
+			Node struct {
+				Items starListItems `json:"items"`
+			} `json:"node"`
+		}
+		vars := map[string]any{"id": listID, "cursor": page.PageInfo.EndCursor}
+		if err := c.GraphQL(starListItemsQuery, vars, &data); err != nil {
+			return nil, err
+		}
+		page = data.Node.Items
+	}
+
+	return items, nil
+}
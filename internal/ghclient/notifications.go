@@ -0,0 +1,28 @@
+package ghclient
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+)
+
+// Mark a notification thread as read
+func (c *Client) MarkThreadRead(id string) error {
+	return c.sendExpect("PATCH", threadEndpoint(id), nil, http.StatusResetContent)
+}
+
+// Mark a notification thread as done, removing it from the inbox
+func (c *Client) MarkThreadDone(id string) error {
+	return c.sendExpect("DELETE", threadEndpoint(id), nil, http.StatusNoContent)
+}
+
+// Mute a notification thread, so future activity doesn't notify
+func (c *Client) MuteThread(id string) error {
+	return c.sendExpect("PUT", threadEndpoint(id)+"/subscription", map[string]bool{"ignored": true}, http.StatusOK)
+}
+
+// Endpoint for a single notification thread
+func threadEndpoint(id string) string {
+	return constants.GH_NOTIFICATIONS_ENDPOINT + "/threads/" + url.PathEscape(id)
+}
@@ -0,0 +1,95 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Fetch every page of a list endpoint by following Link headers
+func FetchAllPages[T any](client *Client, url string, requestSleep int) ([]T, error) {
+	// Initialize array for storing results from all pages
+	var all []T
+	// Initialize page count
+	page := 1
+
+	for {
+		fmt.Fprintf(os.Stderr, "Fetching page %d: %s\n", page, url)
+
+		// Build request with Accept & Authorization headers
+		req, err := client.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		// Make HTTP request
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// Check for unexpected status
+		if resp.StatusCode != 200 {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		}
+
+		// Read response body
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body: %v", err)
+		}
+
+		// Unmarshal this page of results
+		var items []T
+		if err := json.Unmarshal(bodyBytes, &items); err != nil {
+			return nil, fmt.Errorf("error unmarshaling JSON: %w", err)
+		}
+		// Append loop's results to all results
+		all = append(all, items...)
+
+		fmt.Fprintf(os.Stderr, "  Got %d results (total so far: %d)\n", len(items), len(all))
+
+		// Check for next page
+		linkHeader := resp.Header.Get("Link")
+		nextURL := ParseNextURL(linkHeader)
+		if nextURL == "" {
+			// No more pages
+			break
+		}
+
+		// Increment page count
+		page++
+
+		// Wait before next request
+		time.Sleep(time.Duration(requestSleep) * time.Second)
+		// Set URL for next loop
+		url = nextURL
+	}
+
+	return all, nil
+}
+
+// Extract next URL from a Link header
+func ParseNextURL(linkHeader string) string {
+	// Parse Link header
+	parts := strings.Split(linkHeader, ",")
+
+	for _, p := range parts {
+		// Find rel="next"
+		if strings.Contains(p, `rel="next"`) {
+			// Extract URL
+			start := strings.Index(p, "<")
+			end := strings.Index(p, ">")
+			if start != -1 && end != -1 && end > start {
+				return p[start+1 : end]
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,117 @@
+package ghclient
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Classic token scopes that grant other scopes.
+// Notifications can be read with either the notifications or repo scope.
+var impliedScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events", "notifications"},
+	"admin:org":        {"write:org", "read:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"write:discussion": {"read:discussion"},
+	"project":          {"read:project"},
+}
+
+// Result of checking a token before a long run
+type PreflightResult struct {
+	// Authenticated user, empty for Github App installations
+	Login string
+	// Scopes granted to a classic token
+	Scopes []string
+	// Fine-grained tokens & Apps don't report scopes, so they can't be checked up front
+	ScopesKnown   bool
+	MissingScopes []string
+	Expires       time.Time
+	RateLimit     Github.RateLimit
+}
+
+// Returned when a classic token is missing scopes a command needs
+type MissingScopesError struct {
+	Missing []string
+	Scopes  []string
+}
+
+func (e *MissingScopesError) Error() string {
+	return fmt.Sprintf("token is missing required scopes: %s (token has: %s). Add them to the token, or run 'mygithub auth login --device --scopes %s'",
+		strings.Join(e.Missing, ", "), scopeList(e.Scopes), strings.Join(append(slices.Clone(e.Scopes), e.Missing...), ","))
+}
+
+// Check the token works, has the required scopes, & has requests remaining.
+// Set checkUser to false for Github App installations, which can't call /user.
+func (c *Client) Preflight(required []string, checkUser bool) (*PreflightResult, error) {
+	result := &PreflightResult{}
+
+	if checkUser {
+		// Validate token & read its scopes
+		user, resp, err := c.GetUser()
+		if err != nil {
+			return nil, err
+		}
+
+		result.Login = user.Login
+		result.Scopes = ParseScopes(resp.Header)
+		result.ScopesKnown = len(resp.Header.Values("X-OAuth-Scopes")) > 0
+		result.Expires, _ = ParseTokenExpiration(resp.Header)
+
+		if result.ScopesKnown {
+			result.MissingScopes = MissingScopes(result.Scopes, required)
+		}
+	}
+
+	// Check remaining requests
+	var limits Github.RateLimits
+	if _, err := c.GetJSON(constants.GH_RATE_LIMIT_ENDPOINT, &limits); err != nil {
+		return nil, err
+	}
+	result.RateLimit = limits.Resources.Core
+
+	if len(result.MissingScopes) > 0 {
+		return result, &MissingScopesError{Missing: result.MissingScopes, Scopes: result.Scopes}
+	}
+
+	if result.RateLimit.Limit > 0 && result.RateLimit.Remaining == 0 {
+		return result, &APIError{
+			StatusCode:     403,
+			Method:         "GET",
+			URL:            c.BaseURL + constants.GH_RATE_LIMIT_ENDPOINT,
+			Message:        "no requests remaining",
+			RateLimitReset: time.Unix(result.RateLimit.Reset, 0),
+		}
+	}
+
+	return result, nil
+}
+
+// Return the required scopes not granted by a token's scopes
+func MissingScopes(has []string, required []string) []string {
+	granted := map[string]bool{}
+	for _, s := range has {
+		granted[s] = true
+		for _, implied := range impliedScopes[s] {
+			granted[implied] = true
+		}
+	}
+
+	var missing []string
+	for _, s := range required {
+		if !granted[s] {
+			missing = append(missing, s)
+		}
+	}
+
+	return missing
+}
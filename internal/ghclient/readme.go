@@ -0,0 +1,35 @@
+package ghclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Get a repository's README as raw text, or nil if it has none
+func (c *Client) GetReadme(repo Github.Repository) ([]byte, error) {
+	req, err := c.NewRequest("GET", constants.GH_REPOS_ENDPOINT+"/"+repo.FullName+"/readme", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", constants.GH_RAW_ACCEPT_HEADER)
+
+	resp, err := c.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,28 @@
+package ghclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Releases endpoint for a repository, from its releases_url template
+func ReleasesEndpoint(repo Github.Repository) string {
+	if repo.ReleasesURL != "" {
+		return strings.TrimSuffix(repo.ReleasesURL, "{/id}")
+	}
+
+	return constants.GH_REPOS_ENDPOINT + "/" + repo.FullName + "/releases"
+}
+
+// Get a repository's most recent releases, newest first, up to count
+func (c *Client) GetReleases(repo Github.Repository, count int) ([]Github.Release, error) {
+	var releases []Github.Release
+	if _, err := c.GetJSON(fmt.Sprintf("%s?per_page=%d", ReleasesEndpoint(repo), count), &releases); err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
@@ -0,0 +1,122 @@
+package ghclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Get a repository by its "owner/repo" name
+func (c *Client) GetRepo(fullName string) (*Github.Repository, error) {
+	var repo Github.Repository
+	if _, err := c.GetJSON(constants.GH_REPOS_ENDPOINT+"/"+fullName, &repo); err != nil {
+		return nil, err
+	}
+
+	return &repo, nil
+}
+
+// Fetch all of the authenticated user's starred repositories, with the date each was starred
+func (c *Client) FetchStarredRepos(requestSleep int) ([]Github.Repository, error) {
+	// The "star" media type wraps each repository with its starred_at date
+	starClient := *c
+	starClient.Accept = constants.GH_STAR_ACCEPT_HEADER
+
+	stars, err := FetchAllPages[Github.StarredRepository](&starClient, constants.GH_STARRED_ENDPOINT, requestSleep)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make([]Github.Repository, len(stars))
+	for i, star := range stars {
+		repos[i] = star.Repo
+		repos[i].StarredAt = &star.StarredAt
+	}
+
+	return repos, nil
+}
+
+// Star a repository for the authenticated user
+func (c *Client) StarRepo(fullName string) error {
+	return c.sendNoContent("PUT", constants.GH_STARRED_ENDPOINT+"/"+fullName)
+}
+
+// Unstar a repository for the authenticated user
+func (c *Client) UnstarRepo(fullName string) error {
+	return c.sendNoContent("DELETE", constants.GH_STARRED_ENDPOINT+"/"+fullName)
+}
+
+// Stop watching a repository for the authenticated user
+func (c *Client) UnwatchRepo(fullName string) error {
+	return c.sendNoContent("DELETE", constants.GH_REPOS_ENDPOINT+"/"+fullName+"/subscription")
+}
+
+// Send a request with no body that expects "204 No Content"
+func (c *Client) sendNoContent(method string, endpoint string) error {
+	return c.sendExpect(method, endpoint, nil, http.StatusNoContent)
+}
+
+// Send a request with an optional JSON body, expecting a status with no useful response body
+func (c *Client) sendExpect(method string, endpoint string, body any, status int) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding request body: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := c.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		// Github requires Content-Length for PUT requests without a body
+		req.ContentLength = 0
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	// Check for unexpected status
+	if resp.StatusCode != status {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Normalize a repository reference to "owner/repo".
+// Accepts "owner/repo", Github URLs, & clone URLs.
+func ParseRepoName(ref string) (string, error) {
+	name := strings.TrimSpace(ref)
+
+	// Strip scheme & host from URLs
+	if u, err := url.Parse(name); err == nil && u.Host != "" {
+		name = u.Path
+	}
+	name = strings.TrimPrefix(name, "git@github.com:")
+	name = strings.Trim(name, "/")
+	name = strings.TrimSuffix(name, ".git")
+
+	parts := strings.Split(name, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid repository %q (expected owner/repo)", ref)
+	}
+
+	return parts[0] + "/" + parts[1], nil
+}
@@ -0,0 +1,171 @@
+/* HTTP client for the Github REST API. */
+package ghclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/auth"
+	"github.com/redjax/go-mygithub/internal/constants"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Client for making authenticated requests to the Github API
+type Client struct {
+	BaseURL    string
+	GraphQLURL string
+	Accept     string
+	Tokens     auth.TokenSource
+	HTTP       *http.Client
+}
+
+// Create a new Github API client
+func New(baseURL string, tokens auth.TokenSource, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	return &Client{
+		BaseURL:    baseURL,
+		GraphQLURL: baseURL + constants.GH_GRAPHQL_ENDPOINT,
+		Accept:     constants.GH_API_ACCCEPT_HEADER,
+		Tokens:     tokens,
+		HTTP:       httpClient,
+	}
+}
+
+// Build a request with Github headers set. Endpoints starting with "/" are joined to BaseURL.
+func (c *Client) NewRequest(method string, endpoint string, body io.Reader) (*http.Request, error) {
+	url := endpoint
+	if strings.HasPrefix(endpoint, "/") {
+		url = c.BaseURL + endpoint
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	// Set request headers
+	req.Header.Set("Accept", c.Accept)
+	if c.Tokens != nil {
+		token, err := c.Tokens.Token()
+		if err != nil {
+			return nil, fmt.Errorf("error getting access token: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	return req, nil
+}
+
+// Send a request. Error responses are returned as *APIError.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error making request: %w", err)
+	}
+
+	if err := CheckResponse(resp); err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// GET an endpoint & unmarshal the JSON response into v
+func (c *Client) GetJSON(endpoint string, v any) (*http.Response, error) {
+	req, err := c.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, fmt.Errorf("error reading response body: %v", err)
+	}
+
+	if err := json.Unmarshal(bodyBytes, v); err != nil {
+		return resp, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Get the authenticated user
+func (c *Client) GetUser() (*Github.User, *http.Response, error) {
+	var user Github.User
+	resp, err := c.GetJSON(constants.GH_USER_ENDPOINT, &user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &user, resp, nil
+}
+
+// Check whether the authenticated user is an active member of an organization.
+// Needs the read:org scope to see private memberships.
+func (c *Client) IsOrgMember(org string) (bool, error) {
+	var membership struct {
+		State string `json:"state"`
+	}
+	_, err := c.GetJSON(fmt.Sprintf(constants.GH_ORG_MEMBERSHIP_ENDPOINT, url.PathEscape(org)), &membership)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Invitations not yet accepted are "pending"
+	return membership.State == "active", nil
+}
+
+// Parse OAuth scopes granted to a classic token from the X-OAuth-Scopes header
+func ParseScopes(header http.Header) []string {
+	return parseScopeHeader(header.Get("X-OAuth-Scopes"))
+}
+
+// Split a comma-separated scopes header
+func parseScopeHeader(val string) []string {
+	var scopes []string
+	for _, s := range strings.Split(val, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	return scopes
+}
+
+// Parse the token expiration time, if the token expires
+func ParseTokenExpiration(header http.Header) (time.Time, bool) {
+	val := header.Get("GitHub-Authentication-Token-Expiration")
+	if val == "" {
+		return time.Time{}, false
+	}
+
+	// Github returns i.e. "2023-09-01 00:00:00 UTC" or "2023-09-01 00:00:00 -0700"
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"} {
+		if t, err := time.Parse(layout, val); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,387 @@
+package ghclient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Starred repositories with only the fields we use, 100 per page
+const starredReposQuery = `
+query($cursor: String) {
+  viewer {
+    starredRepositories(first: 100, after: $cursor, orderBy: {field: STARRED_AT, direction: DESC}) {
+      totalCount
+      pageInfo { hasNextPage endCursor }
+      edges {
+        starredAt
+        node {
+          databaseId
+          id
+          name
+          nameWithOwner
+          description
+          url
+          sshUrl
+          homepageUrl
+          mirrorUrl
+          isPrivate
+          isFork
+          isArchived
+          isDisabled
+          isTemplate
+          visibility
+          createdAt
+          updatedAt
+          pushedAt
+          diskUsage
+          stargazerCount
+          forkCount
+          hasIssuesEnabled
+          hasProjectsEnabled
+          hasWikiEnabled
+          hasDiscussionsEnabled
+          forkingAllowed
+          webCommitSignoffRequired
+          viewerPermission
+          owner {
+            __typename
+            login
+            id
+            avatarUrl
+            url
+            ... on User { databaseId }
+            ... on Organization { databaseId }
+          }
+          primaryLanguage { name }
+          languages(first: 100, orderBy: {field: SIZE, direction: DESC}) {
+            edges { size node { name } }
+          }
+          repositoryTopics(first: 20) { nodes { topic { name } } }
+          licenseInfo { key name spdxId url id }
+          latestRelease { databaseId id tagName name url isDraft isPrerelease createdAt publishedAt }
+          defaultBranchRef { name }
+          issues(states: OPEN) { totalCount }
+          pullRequests(states: OPEN) { totalCount }
+        }
+      }
+    }
+  }
+}`
+
+// GraphQL Repository node selected by starredReposQuery
+type starredRepoNode struct {
+	DatabaseID            int       `json:"databaseId"`
+	ID                    string    `json:"id"`
+	Name                  string    `json:"name"`
+	NameWithOwner         string    `json:"nameWithOwner"`
+	Description           *string   `json:"description"`
+	URL                   string    `json:"url"`
+	SshURL                string    `json:"sshUrl"`
+	HomepageURL           *string   `json:"homepageUrl"`
+	MirrorURL             *string   `json:"mirrorUrl"`
+	IsPrivate             bool      `json:"isPrivate"`
+	IsFork                bool      `json:"isFork"`
+	IsArchived            bool      `json:"isArchived"`
+	IsDisabled            bool      `json:"isDisabled"`
+	IsTemplate            bool      `json:"isTemplate"`
+	Visibility            string    `json:"visibility"`
+	CreatedAt             time.Time `json:"createdAt"`
+	UpdatedAt             time.Time `json:"updatedAt"`
+	PushedAt              time.Time `json:"pushedAt"`
+	DiskUsage             int       `json:"diskUsage"`
+	StargazerCount        int       `json:"stargazerCount"`
+	ForkCount             int       `json:"forkCount"`
+	HasIssuesEnabled      bool      `json:"hasIssuesEnabled"`
+	HasProjectsEnabled    bool      `json:"hasProjectsEnabled"`
+	HasWikiEnabled        bool      `json:"hasWikiEnabled"`
+	HasDiscussionsEnabled bool      `json:"hasDiscussionsEnabled"`
+	ForkingAllowed        bool      `json:"forkingAllowed"`
+	WebCommitSignoff      bool      `json:"webCommitSignoffRequired"`
+	ViewerPermission      *string   `json:"viewerPermission"`
+	Owner                 struct {
+		Typename   string `json:"__typename"`
+		Login      string `json:"login"`
+		ID         string `json:"id"`
+		AvatarURL  string `json:"avatarUrl"`
+		URL        string `json:"url"`
+		DatabaseID int    `json:"databaseId"`
+	} `json:"owner"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	Languages struct {
+		Edges []struct {
+			Size int `json:"size"`
+			Node struct {
+				Name string `json:"name"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"languages"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	LicenseInfo *struct {
+		Key    string  `json:"key"`
+		Name   string  `json:"name"`
+		SPDXID *string `json:"spdxId"`
+		URL    *string `json:"url"`
+		ID     string  `json:"id"`
+	} `json:"licenseInfo"`
+	LatestRelease *struct {
+		DatabaseID   int        `json:"databaseId"`
+		ID           string     `json:"id"`
+		TagName      string     `json:"tagName"`
+		Name         *string    `json:"name"`
+		URL          string     `json:"url"`
+		IsDraft      bool       `json:"isDraft"`
+		IsPrerelease bool       `json:"isPrerelease"`
+		CreatedAt    time.Time  `json:"createdAt"`
+		PublishedAt  *time.Time `json:"publishedAt"`
+	} `json:"latestRelease"`
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	Issues struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"issues"`
+	PullRequests struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"pullRequests"`
+}
+
+// Fetch all of the authenticated user's starred repositories from the GraphQL API.
+// Falls back to the REST API if the server has no GraphQL API (i.e. older Github Enterprise).
+func (c *Client) FetchStarredReposGraphQL(requestSleep int) ([]Github.Repository, error) {
+	var repos []Github.Repository
+	var cursor *string
+	page := 1
+
+	for {
+		fmt.Fprintf(os.Stderr, "Fetching page %d: %s\n", page, c.GraphQLURL)
+
+		var data struct {
+			Viewer struct {
+				StarredRepositories struct {
+					TotalCount int      `json:"totalCount"`
+					PageInfo   pageInfo `json:"pageInfo"`
+					Edges      []struct {
+						StarredAt time.Time       `json:"starredAt"`
+						Node      starredRepoNode `json:"node"`
+					} `json:"edges"`
+				} `json:"starredRepositories"`
+			} `json:"viewer"`
+		}
+		err := c.GraphQL(starredReposQuery, map[string]any{"cursor": cursor}, &data)
+		if errors.Is(err, ErrNotFound) && cursor == nil {
+			fmt.Fprintf(os.Stderr, "No GraphQL API at %s, falling back to the REST API\n", c.GraphQLURL)
+			return c.FetchStarredRepos(requestSleep)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		stars := data.Viewer.StarredRepositories
+		for _, edge := range stars.Edges {
+			repo := c.convertStarredRepoNode(edge.Node)
+			starredAt := edge.StarredAt
+			repo.StarredAt = &starredAt
+			repos = append(repos, repo)
+		}
+
+		fmt.Fprintf(os.Stderr, "  Got %d results (total so far: %d of %d)\n", len(stars.Edges), len(repos), stars.TotalCount)
+
+		// Check for next page
+		if !stars.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &stars.PageInfo.EndCursor
+		page++
+
+		// Wait before next request
+		time.Sleep(time.Duration(requestSleep) * time.Second)
+	}
+
+	return repos, nil
+}
+
+// Map a GraphQL repository node into the REST repository schema
+func (c *Client) convertStarredRepoNode(node starredRepoNode) Github.Repository {
+	apiURL := fmt.Sprintf("%s/repos/%s", strings.TrimRight(c.BaseURL, "/"), node.NameWithOwner)
+
+	// REST counts open pull requests as issues
+	openIssues := node.Issues.TotalCount + node.PullRequests.TotalCount
+
+	repo := Github.Repository{
+		ID:                       node.DatabaseID,
+		NodeID:                   node.ID,
+		Name:                     node.Name,
+		FullName:                 node.NameWithOwner,
+		Private:                  node.IsPrivate,
+		HTMLURL:                  node.URL,
+		Description:              node.Description,
+		Fork:                     node.IsFork,
+		URL:                      apiURL,
+		ForksURL:                 apiURL + "/forks",
+		KeysURL:                  apiURL + "/keys{/key_id}",
+		CollaboratorsURL:         apiURL + "/collaborators{/collaborator}",
+		TeamsURL:                 apiURL + "/teams",
+		HooksURL:                 apiURL + "/hooks",
+		IssueEventsURL:           apiURL + "/issues/events{/number}",
+		EventsURL:                apiURL + "/events",
+		AssigneesURL:             apiURL + "/assignees{/user}",
+		BranchesURL:              apiURL + "/branches{/branch}",
+		TagsURL:                  apiURL + "/tags",
+		BlobsURL:                 apiURL + "/git/blobs{/sha}",
+		GitTagsURL:               apiURL + "/git/tags{/sha}",
+		GitRefsURL:               apiURL + "/git/refs{/sha}",
+		TreesURL:                 apiURL + "/git/trees{/sha}",
+		StatusesURL:              apiURL + "/statuses/{sha}",
+		LanguagesURL:             apiURL + "/languages",
+		StargazersURL:            apiURL + "/stargazers",
+		ContributorsURL:          apiURL + "/contributors",
+		SubscribersURL:           apiURL + "/subscribers",
+		SubscriptionURL:          apiURL + "/subscription",
+		CommitsURL:               apiURL + "/commits{/sha}",
+		GitCommitsURL:            apiURL + "/git/commits{/sha}",
+		CommentsURL:              apiURL + "/comments{/number}",
+		IssueCommentURL:          apiURL + "/issues/comments{/number}",
+		ContentsURL:              apiURL + "/contents/{+path}",
+		CompareURL:               apiURL + "/compare/{base}...{head}",
+		MergesURL:                apiURL + "/merges",
+		ArchiveURL:               apiURL + "/{archive_format}{/ref}",
+		DownloadsURL:             apiURL + "/downloads",
+		IssuesURL:                apiURL + "/issues{/number}",
+		PullsURL:                 apiURL + "/pulls{/number}",
+		MilestonesURL:            apiURL + "/milestones{/number}",
+		NotificationsURL:         apiURL + "/notifications{?since,all,participating}",
+		LabelsURL:                apiURL + "/labels{/name}",
+		ReleasesURL:              apiURL + "/releases{/id}",
+		DeploymentsURL:           apiURL + "/deployments",
+		CreatedAt:                node.CreatedAt,
+		UpdatedAt:                node.UpdatedAt,
+		PushedAt:                 node.PushedAt,
+		GitURL:                   "git://" + strings.TrimPrefix(strings.TrimPrefix(node.URL, "https://"), "http://") + ".git",
+		SshURL:                   node.SshURL,
+		CloneURL:                 node.URL + ".git",
+		SvnURL:                   node.URL,
+		Homepage:                 node.HomepageURL,
+		Size:                     node.DiskUsage,
+		StargazersCount:          node.StargazerCount,
+		WatchersCount:            node.StargazerCount,
+		HasIssues:                node.HasIssuesEnabled,
+		HasProjects:              node.HasProjectsEnabled,
+		HasWiki:                  node.HasWikiEnabled,
+		HasDiscussions:           node.HasDiscussionsEnabled,
+		ForksCount:               node.ForkCount,
+		MirrorURL:                node.MirrorURL,
+		Archived:                 node.IsArchived,
+		Disabled:                 node.IsDisabled,
+		OpenIssuesCount:          openIssues,
+		AllowForking:             node.ForkingAllowed,
+		IsTemplate:               node.IsTemplate,
+		WebCommitSignoffRequired: node.WebCommitSignoff,
+		Visibility:               strings.ToLower(node.Visibility),
+		Forks:                    node.ForkCount,
+		OpenIssues:               openIssues,
+		Watchers:                 node.StargazerCount,
+		Topics:                   []string{},
+		Permissions:              convertViewerPermission(node.ViewerPermission),
+		Owner: Github.RepositoryOwner{
+			Login:     node.Owner.Login,
+			ID:        node.Owner.DatabaseID,
+			NodeID:    node.Owner.ID,
+			AvatarURL: node.Owner.AvatarURL,
+			URL:       fmt.Sprintf("%s/users/%s", strings.TrimRight(c.BaseURL, "/"), node.Owner.Login),
+			HTMLURL:   node.Owner.URL,
+			Type:      node.Owner.Typename,
+		},
+	}
+
+	// REST returns null for an unset homepage, GraphQL may return ""
+	if repo.Homepage != nil && *repo.Homepage == "" {
+		repo.Homepage = nil
+	}
+
+	if node.PrimaryLanguage != nil {
+		language := node.PrimaryLanguage.Name
+		repo.Language = &language
+	}
+
+	// Empty rather than nil when the repository has no languages, so stored ones are cleared
+	repo.Languages = make(map[string]int, len(node.Languages.Edges))
+	for _, edge := range node.Languages.Edges {
+		repo.Languages[edge.Node.Name] = edge.Size
+	}
+
+	for _, t := range node.RepositoryTopics.Nodes {
+		repo.Topics = append(repo.Topics, t.Topic.Name)
+	}
+
+	if node.LicenseInfo != nil {
+		license := node.LicenseInfo
+		repo.License = &Github.RepositoryLicense{
+			Key:    &license.Key,
+			Name:   &license.Name,
+			SPDXID: license.SPDXID,
+			URL:    license.URL,
+			NodeID: &license.ID,
+		}
+	}
+
+	if node.LatestRelease != nil {
+		release := node.LatestRelease
+		repo.LatestRelease = &Github.Release{
+			ID:          release.DatabaseID,
+			NodeID:      release.ID,
+			TagName:     release.TagName,
+			Name:        release.Name,
+			HTMLURL:     release.URL,
+			Draft:       release.IsDraft,
+			Prerelease:  release.IsPrerelease,
+			CreatedAt:   release.CreatedAt,
+			PublishedAt: release.PublishedAt,
+		}
+	}
+
+	if node.DefaultBranchRef != nil {
+		repo.DefaultBranch = node.DefaultBranchRef.Name
+	}
+
+	return repo
+}
+
+// Map the viewer's GraphQL permission level to REST permission flags, nil if unknown
+func convertViewerPermission(level *string) *Github.RepositoryPermissions {
+	if level == nil {
+		return nil
+	}
+
+	// Each level includes the ones below it
+	levels := []string{"READ", "TRIAGE", "WRITE", "MAINTAIN", "ADMIN"}
+	rank := slices.Index(levels, *level)
+	if rank < 0 {
+		return nil
+	}
+	has := func(l string) *bool {
+		ok := rank >= slices.Index(levels, l)
+		return &ok
+	}
+
+	return &Github.RepositoryPermissions{
+		Admin:    has("ADMIN"),
+		Maintain: has("MAINTAIN"),
+		Push:     has("WRITE"),
+		Triage:   has("TRIAGE"),
+		Pull:     has("READ"),
+	}
+}
@@ -0,0 +1,203 @@
+package ghclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/redjax/go-mygithub/internal/auth"
+	"github.com/redjax/go-mygithub/internal/constants"
+)
+
+// Recorded pages of starredRepositories, keyed by the cursor that requests them
+var starredPages = map[string]string{
+	"": `{"data":{"viewer":{"starredRepositories":{
+		"totalCount": 2,
+		"pageInfo": {"hasNextPage": true, "endCursor": "c1"},
+		"edges": [{
+			"starredAt": "2024-06-01T00:00:00Z",
+			"node": {
+				"databaseId": 2, "id": "R_2", "name": "b", "nameWithOwner": "o/b",
+				"description": "bee", "url": "https://github.com/o/b", "sshUrl": "git@github.com:o/b.git",
+				"homepageUrl": "", "visibility": "PUBLIC", "pushedAt": "2025-01-01T00:00:00Z",
+				"stargazerCount": 51, "forkCount": 3, "forkingAllowed": true, "viewerPermission": "WRITE",
+				"owner": {"__typename": "User", "login": "o", "id": "U_10", "url": "https://github.com/o", "databaseId": 10},
+				"primaryLanguage": {"name": "Rust"},
+				"languages": {"edges": [{"size": 900, "node": {"name": "Rust"}}, {"size": 100, "node": {"name": "Shell"}}]},
+				"repositoryTopics": {"nodes": [{"topic": {"name": "cli"}}]},
+				"licenseInfo": {"key": "mit", "name": "MIT License", "spdxId": "MIT", "id": "L_1"},
+				"latestRelease": {"databaseId": 7, "id": "RE_7", "tagName": "v1.0.0", "url": "https://github.com/o/b/releases/v1.0.0", "createdAt": "2025-01-01T00:00:00Z"},
+				"defaultBranchRef": {"name": "main"},
+				"issues": {"totalCount": 4},
+				"pullRequests": {"totalCount": 2}
+			}
+		}]
+	}}}}`,
+	"c1": `{"data":{"viewer":{"starredRepositories":{
+		"totalCount": 2,
+		"pageInfo": {"hasNextPage": false, "endCursor": "c2"},
+		"edges": [{
+			"starredAt": "2024-05-01T00:00:00Z",
+			"node": {
+				"databaseId": 1, "id": "R_1", "name": "a", "nameWithOwner": "o/a",
+				"url": "https://github.com/o/a", "isArchived": true, "visibility": "PUBLIC",
+				"owner": {"__typename": "Organization", "login": "o", "id": "O_10", "url": "https://github.com/o", "databaseId": 10},
+				"languages": {"edges": []},
+				"repositoryTopics": {"nodes": []},
+				"issues": {"totalCount": 0},
+				"pullRequests": {"totalCount": 0}
+			}
+		}]
+	}}}}`,
+}
+
+// Serve recorded starredRepositories pages, recording the cursor of each request
+func newStarredGraphQLServer(t *testing.T, cursors *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != constants.GH_GRAPHQL_ENDPOINT {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Authorization = %q, want Bearer token", got)
+		}
+
+		var req struct {
+			Query     string `json:"query"`
+			Variables struct {
+				Cursor *string `json:"cursor"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+			return
+		}
+
+		cursor := ""
+		if req.Variables.Cursor != nil {
+			cursor = *req.Variables.Cursor
+		}
+		*cursors = append(*cursors, cursor)
+
+		page, ok := starredPages[cursor]
+		if !ok {
+			t.Errorf("unexpected cursor %q", cursor)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+}
+
+func TestFetchStarredReposGraphQLPaginates(t *testing.T) {
+	var cursors []string
+	srv := newStarredGraphQLServer(t, &cursors)
+	defer srv.Close()
+
+	client := New(srv.URL, auth.StaticTokenSource("token"), nil)
+	repos, err := client.FetchStarredReposGraphQL(0)
+	if err != nil {
+		t.Fatalf("FetchStarredReposGraphQL: %v", err)
+	}
+
+	if strings.Join(cursors, ",") != ",c1" {
+		t.Errorf("requested cursors %q, want first page then c1", cursors)
+	}
+	if len(repos) != 2 || repos[0].FullName != "o/b" || repos[1].FullName != "o/a" {
+		t.Fatalf("repos = %+v, want o/b then o/a", repos)
+	}
+
+	b := repos[0]
+	if b.StarredAt == nil || b.StarredAt.Format("2006-01-02") != "2024-06-01" {
+		t.Errorf("StarredAt = %v, want 2024-06-01", b.StarredAt)
+	}
+	if b.Homepage != nil {
+		t.Errorf("Homepage = %q, want nil for an empty homepage", *b.Homepage)
+	}
+	if b.Language == nil || *b.Language != "Rust" || b.Languages["Shell"] != 100 {
+		t.Errorf("Language = %v, Languages = %v", b.Language, b.Languages)
+	}
+	if b.License == nil || *b.License.Key != "mit" || b.LatestRelease == nil || b.LatestRelease.TagName != "v1.0.0" {
+		t.Errorf("License = %+v, LatestRelease = %+v", b.License, b.LatestRelease)
+	}
+	if b.Visibility != "public" || b.DefaultBranch != "main" || b.OpenIssuesCount != 6 || len(b.Topics) != 1 {
+		t.Errorf("Visibility = %q, DefaultBranch = %q, OpenIssuesCount = %d, Topics = %v", b.Visibility, b.DefaultBranch, b.OpenIssuesCount, b.Topics)
+	}
+
+	// Fields GraphQL doesn't return are filled in the REST format
+	if b.URL != srv.URL+"/repos/o/b" || b.ForksURL != srv.URL+"/repos/o/b/forks" {
+		t.Errorf("URL = %q, ForksURL = %q", b.URL, b.ForksURL)
+	}
+	if b.SshURL != "git@github.com:o/b.git" || b.GitURL != "git://github.com/o/b.git" || b.CloneURL != "https://github.com/o/b.git" {
+		t.Errorf("SshURL = %q, GitURL = %q, CloneURL = %q", b.SshURL, b.GitURL, b.CloneURL)
+	}
+	if p := b.Permissions; p == nil || *p.Admin || *p.Maintain || !*p.Push || !*p.Triage || !*p.Pull {
+		t.Errorf("Permissions = %+v, want push, triage & pull", p)
+	}
+
+	a := repos[1]
+	if !a.Archived || a.Owner.Type != "Organization" || a.Language != nil || a.Permissions != nil {
+		t.Errorf("o/a = %+v", a)
+	}
+	if a.Languages == nil || len(a.Languages) != 0 {
+		t.Errorf("Languages = %v, want empty so stored languages are cleared", a.Languages)
+	}
+}
+
+func TestFetchStarredReposGraphQLErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": null, "errors": [
+			{"type": "NOT_FOUND", "message": "Could not resolve", "path": ["viewer", "starredRepositories", "edges", 0, "node"]},
+			{"message": "Something else"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, auth.StaticTokenSource("token"), nil)
+	_, err := client.FetchStarredReposGraphQL(0)
+
+	var gqlErrs GraphQLErrors
+	if !errors.As(err, &gqlErrs) || len(gqlErrs) != 2 {
+		t.Fatalf("error = %v, want 2 GraphQL errors", err)
+	}
+	want := "graphql: NOT_FOUND: Could not resolve (at viewer.starredRepositories.edges.0.node); Something else"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFetchStarredReposGraphQLFallsBackToREST(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case constants.GH_GRAPHQL_ENDPOINT:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+
+		case constants.GH_STARRED_ENDPOINT:
+			if got := r.Header.Get("Accept"); got != constants.GH_STAR_ACCEPT_HEADER {
+				t.Errorf("Accept = %q, want the star media type", got)
+			}
+			w.Write([]byte(`[{"starred_at": "2024-06-01T00:00:00Z", "repo": {"id": 2, "full_name": "o/b", "ssh_url": "git@github.com:o/b.git"}}]`))
+
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := New(srv.URL, auth.StaticTokenSource("token"), nil)
+	repos, err := client.FetchStarredReposGraphQL(0)
+	if err != nil {
+		t.Fatalf("FetchStarredReposGraphQL: %v", err)
+	}
+	if len(repos) != 1 || repos[0].FullName != "o/b" || repos[0].SshURL != "git@github.com:o/b.git" {
+		t.Fatalf("repos = %+v, want o/b from the REST API", repos)
+	}
+	if repos[0].StarredAt == nil || repos[0].StarredAt.Format("2006-01-02") != "2024-06-01" {
+		t.Errorf("StarredAt = %v, want 2024-06-01", repos[0].StarredAt)
+	}
+}
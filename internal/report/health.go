@@ -0,0 +1,244 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Maintenance health of one repository
+type RepoHealth struct {
+	FullName         string     `json:"full_name"`
+	HTMLURL          string     `json:"html_url"`
+	Score            int        `json:"score"` // 0-100, higher is healthier
+	LikelyDead       bool       `json:"likely_dead"`
+	Archived         bool       `json:"archived"`
+	PushedAt         time.Time  `json:"pushed_at"`
+	DaysSincePush    int        `json:"days_since_push"`
+	HasIssues        bool       `json:"has_issues"`
+	OpenIssues       int        `json:"open_issues"`
+	License          string     `json:"license"`
+	LastRelease      *time.Time `json:"last_release"`       // nil if no releases are stored
+	ReleasesLastYear int        `json:"releases_last_year"` // of the stored recent releases
+	CadenceDays      int        `json:"cadence_days"`       // median days between stored releases, 0 if fewer than 2
+	Contributors     *int       `json:"contributors"`       // nil if not enriched
+	Flags            []string   `json:"flags"`
+}
+
+// Points per health signal. Signals without data, i.e. releases for a
+// repository that never released, are left out of the score.
+const (
+	activityPoints     = 40
+	issuesPoints       = 10
+	backlogPoints      = 10
+	licensePoints      = 10
+	releasePoints      = 25
+	contributorsPoints = 15
+)
+
+// Score stored repositories, least healthy first.
+// Archived repositories & those without a push in deadMonths are flagged as likely dead.
+func BuildHealth(repos []Github.RepositoryModel, releases []Github.ReleaseModel, contributors map[int]int, deadMonths int, now time.Time) []RepoHealth {
+	// Publish times per repository, newest first
+	published := map[int][]time.Time{}
+	for _, r := range releases {
+		published[r.RepositoryID] = append(published[r.RepositoryID], r.PublishedAt.Time)
+	}
+	for _, times := range published {
+		sort.Slice(times, func(i, j int) bool { return times[i].After(times[j]) })
+	}
+
+	deadBefore := now.AddDate(0, -deadMonths, 0)
+	yearAgo := now.AddDate(-1, 0, 0)
+
+	health := make([]RepoHealth, 0, len(repos))
+	for _, repo := range repos {
+		h := RepoHealth{
+			FullName:      repo.FullName,
+			HTMLURL:       repo.HTMLURL,
+			Archived:      repo.Archived,
+			PushedAt:      repo.PushedAt,
+			DaysSincePush: int(now.Sub(repo.PushedAt).Hours() / 24),
+			HasIssues:     repo.HasIssues,
+			OpenIssues:    repo.OpenIssuesCount,
+			Flags:         []string{},
+		}
+		if repo.License != nil {
+			h.License = repo.License.SPDXID.String
+		}
+
+		earned, possible := 0, 0
+
+		// Recent pushes
+		possible += activityPoints
+		switch months := h.DaysSincePush / 30; {
+		case months < 3:
+			earned += activityPoints
+		case months < 6:
+			earned += activityPoints * 3 / 4
+		case months < 12:
+			earned += activityPoints / 2
+		case months < 24:
+			earned += activityPoints / 5
+		}
+		if h.DaysSincePush >= 365 {
+			h.Flags = append(h.Flags, fmt.Sprintf("no push in %s", ageString(h.DaysSincePush)))
+		}
+
+		// Somewhere to report problems
+		possible += issuesPoints
+		if repo.HasIssues {
+			earned += issuesPoints
+		} else {
+			h.Flags = append(h.Flags, "issues disabled")
+		}
+
+		// Open issues & pull requests relative to popularity, small backlogs are fine either way
+		if repo.HasIssues {
+			possible += backlogPoints
+			perStar := float64(repo.OpenIssuesCount) / float64(max(repo.StargazersCount, 1))
+			switch {
+			case repo.OpenIssuesCount < 10 || perStar < 0.05:
+				earned += backlogPoints
+			case perStar < 0.2:
+				earned += backlogPoints / 2
+			default:
+				h.Flags = append(h.Flags, fmt.Sprintf("%d open issues", repo.OpenIssuesCount))
+			}
+		}
+
+		possible += licensePoints
+		if h.License != "" {
+			earned += licensePoints
+		} else {
+			h.Flags = append(h.Flags, "no license")
+		}
+
+		// Release recency & cadence, for repositories that release
+		if times := published[repo.ID]; len(times) > 0 {
+			h.LastRelease = &times[0]
+			for _, t := range times {
+				if t.After(yearAgo) {
+					h.ReleasesLastYear++
+				}
+			}
+			h.CadenceDays = medianGapDays(times)
+
+			possible += releasePoints
+			sinceRelease := int(now.Sub(times[0]).Hours() / 24)
+			switch {
+			case sinceRelease < 180:
+				earned += releasePoints
+			case sinceRelease < 365:
+				earned += releasePoints * 3 / 5
+			case sinceRelease < 730:
+				earned += releasePoints / 5
+			}
+			if sinceRelease >= 365 {
+				h.Flags = append(h.Flags, fmt.Sprintf("no release in %s", ageString(sinceRelease)))
+			}
+		}
+
+		// Bus factor
+		if count, ok := contributors[repo.ID]; ok {
+			h.Contributors = &count
+
+			possible += contributorsPoints
+			switch {
+			case count >= 10:
+				earned += contributorsPoints
+			case count >= 3:
+				earned += contributorsPoints * 2 / 3
+			case count == 2:
+				earned += contributorsPoints / 3
+			case count == 1:
+				h.Flags = append(h.Flags, "single contributor")
+			default:
+				h.Flags = append(h.Flags, "no contributors")
+			}
+		}
+
+		h.Score = earned * 100 / possible
+
+		// Archived repositories won't get fixes, whatever else they score
+		if repo.Archived {
+			h.Score = 0
+			h.Flags = append([]string{"archived"}, h.Flags...)
+		}
+		h.LikelyDead = repo.Archived || repo.PushedAt.Before(deadBefore)
+
+		health = append(health, h)
+	}
+
+	sort.Slice(health, func(i, j int) bool {
+		if health[i].Score != health[j].Score {
+			return health[i].Score < health[j].Score
+		}
+		return health[i].FullName < health[j].FullName
+	})
+
+	return health
+}
+
+// Median days between consecutive release times, or 0 with fewer than 2
+func medianGapDays(times []time.Time) int {
+	if len(times) < 2 {
+		return 0
+	}
+
+	gaps := make([]int, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		gaps = append(gaps, int(times[i-1].Sub(times[i]).Hours()/24))
+	}
+	sort.Ints(gaps)
+
+	return gaps[len(gaps)/2]
+}
+
+// Days as a rough age, i.e. "8 months" or "3 years"
+func ageString(days int) string {
+	if days >= 730 {
+		return fmt.Sprintf("%d years", days/365)
+	}
+
+	return fmt.Sprintf("%d months", days/30)
+}
+
+// CSV header matching HealthCSVRows
+var HealthCSVHeader = []string{"full_name", "score", "likely_dead", "archived", "days_since_push", "has_issues", "open_issues", "license", "last_release", "releases_last_year", "cadence_days", "contributors", "flags"}
+
+// Flatten health for CSV output
+func HealthCSVRows(health []RepoHealth) [][]string {
+	rows := make([][]string, len(health))
+	for i, h := range health {
+		lastRelease, contributors := "", ""
+		if h.LastRelease != nil {
+			lastRelease = h.LastRelease.Format(time.RFC3339)
+		}
+		if h.Contributors != nil {
+			contributors = strconv.Itoa(*h.Contributors)
+		}
+
+		rows[i] = []string{
+			h.FullName,
+			strconv.Itoa(h.Score),
+			strconv.FormatBool(h.LikelyDead),
+			strconv.FormatBool(h.Archived),
+			strconv.Itoa(h.DaysSincePush),
+			strconv.FormatBool(h.HasIssues),
+			strconv.Itoa(h.OpenIssues),
+			h.License,
+			lastRelease,
+			strconv.Itoa(h.ReleasesLastYear),
+			strconv.Itoa(h.CadenceDays),
+			contributors,
+			strings.Join(h.Flags, "; "),
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Sort orders for language stats
+const (
+	SortByCount = "count"
+	SortByBytes = "bytes"
+)
+
+// Language breakdown across a set of repositories
+type LanguageStats struct {
+	Repositories int             `json:"repositories"`
+	Enriched     int             `json:"enriched"` // repositories with per-language bytes stored
+	TotalBytes   int64           `json:"total_bytes"`
+	Languages    []LanguageTotal `json:"languages"`
+}
+
+// Totals for one language
+type LanguageTotal struct {
+	Language     string  `json:"language"`
+	Repositories int     `json:"repositories"` // repositories with any code in the language
+	Primary      int     `json:"primary"`      // repositories where it's the main language
+	Bytes        int64   `json:"bytes"`
+	BytesShare   float64 `json:"bytes_share"` // percent of all bytes
+}
+
+// Aggregate stored languages of repos, sorted by SortByCount or SortByBytes.
+// Repositories without stored languages count toward their primary language only.
+func BuildLanguageStats(repos []Github.RepositoryModel, languages []Github.RepositoryLanguageModel, sortBy string) LanguageStats {
+	stats := LanguageStats{Repositories: len(repos), Languages: []LanguageTotal{}}
+
+	inSet := map[int]bool{}
+	for _, repo := range repos {
+		inSet[repo.ID] = true
+	}
+
+	totals := map[string]*LanguageTotal{}
+	total := func(language string) *LanguageTotal {
+		t, ok := totals[language]
+		if !ok {
+			t = &LanguageTotal{Language: language}
+			totals[language] = t
+		}
+		return t
+	}
+
+	// Languages with code in each repository
+	enriched := map[int]map[string]bool{}
+	for _, l := range languages {
+		if !inSet[l.RepositoryID] {
+			continue
+		}
+		if enriched[l.RepositoryID] == nil {
+			enriched[l.RepositoryID] = map[string]bool{}
+		}
+		enriched[l.RepositoryID][l.Language] = true
+
+		t := total(l.Language)
+		t.Repositories++
+		t.Bytes += int64(l.Bytes)
+		stats.TotalBytes += int64(l.Bytes)
+	}
+	stats.Enriched = len(enriched)
+
+	for _, repo := range repos {
+		if !repo.Language.Valid || repo.Language.String == "" {
+			continue
+		}
+		t := total(repo.Language.String)
+		t.Primary++
+		if !enriched[repo.ID][repo.Language.String] {
+			t.Repositories++
+		}
+	}
+
+	for _, t := range totals {
+		if stats.TotalBytes > 0 {
+			t.BytesShare = float64(t.Bytes) * 100 / float64(stats.TotalBytes)
+		}
+		stats.Languages = append(stats.Languages, *t)
+	}
+
+	sort.Slice(stats.Languages, func(i, j int) bool {
+		a, b := stats.Languages[i], stats.Languages[j]
+		if sortBy == SortByBytes && a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		if a.Repositories != b.Repositories {
+			return a.Repositories > b.Repositories
+		}
+		if a.Bytes != b.Bytes {
+			return a.Bytes > b.Bytes
+		}
+		return a.Language < b.Language
+	})
+
+	return stats
+}
+
+// CSV header matching LanguageStats.CSVRows
+var LanguageStatsCSVHeader = []string{"language", "repositories", "primary", "bytes", "bytes_share"}
+
+// Flatten language totals for CSV output
+func (s LanguageStats) CSVRows() [][]string {
+	rows := make([][]string, len(s.Languages))
+	for i, l := range s.Languages {
+		rows[i] = []string{
+			l.Language,
+			strconv.Itoa(l.Repositories),
+			strconv.Itoa(l.Primary),
+			strconv.FormatInt(l.Bytes, 10),
+			fmt.Sprintf("%.2f", l.BytesShare),
+		}
+	}
+
+	return rows
+}
+
+// Human-readable byte count, i.e. "1.5 MB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Notification volume for one repository
+type NotificationVolume struct {
+	FullName    string         `json:"full_name"`
+	Total       int            `json:"total"`
+	Unread      int            `json:"unread"`
+	Reasons     map[string]int `json:"reasons"`
+	LastUpdated time.Time      `json:"last_updated"`
+}
+
+// Count stored notifications per repository, busiest first
+func BuildNotificationVolume(notifications []Github.NotificationModel) []NotificationVolume {
+	byRepo := map[string]*NotificationVolume{}
+	for _, n := range notifications {
+		v, ok := byRepo[n.FullName]
+		if !ok {
+			v = &NotificationVolume{FullName: n.FullName, Reasons: map[string]int{}}
+			byRepo[n.FullName] = v
+		}
+
+		v.Total++
+		if n.Unread {
+			v.Unread++
+		}
+		v.Reasons[n.Reason]++
+		if n.UpdatedAt.After(v.LastUpdated) {
+			v.LastUpdated = n.UpdatedAt
+		}
+	}
+
+	volume := make([]NotificationVolume, 0, len(byRepo))
+	for _, v := range byRepo {
+		volume = append(volume, *v)
+	}
+	sort.Slice(volume, func(i, j int) bool {
+		if volume[i].Total != volume[j].Total {
+			return volume[i].Total > volume[j].Total
+		}
+		return volume[i].FullName < volume[j].FullName
+	})
+
+	return volume
+}
+
+// Reasons as "reason=count" pairs, most common first
+func (v NotificationVolume) ReasonSummary() string {
+	var pairs []string
+	for _, reason := range SortedCounts(v.Reasons) {
+		pairs = append(pairs, reason+"="+strconv.Itoa(v.Reasons[reason]))
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// CSV header matching NotificationVolumeCSVRows
+var NotificationVolumeCSVHeader = []string{"full_name", "total", "unread", "reasons", "last_updated"}
+
+// Flatten notification volume for CSV output
+func NotificationVolumeCSVRows(volume []NotificationVolume) [][]string {
+	rows := make([][]string, len(volume))
+	for i, v := range volume {
+		rows[i] = []string{
+			v.FullName,
+			strconv.Itoa(v.Total),
+			strconv.Itoa(v.Unread),
+			v.ReasonSummary(),
+			v.LastUpdated.Format(time.RFC3339),
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Digest of releases published since a point in time
+type ReleaseDigest struct {
+	Since       time.Time       `json:"since"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Releases    []DigestRelease `json:"releases"`
+}
+
+// One release in a digest
+type DigestRelease struct {
+	Repository  string    `json:"repository"`
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	HTMLURL     string    `json:"html_url"`
+	Body        string    `json:"body"`
+}
+
+// Build a digest from stored releases & a map of repository IDs to names
+func BuildReleaseDigest(releases []Github.ReleaseModel, repoNames map[int]string, since time.Time, now time.Time) ReleaseDigest {
+	digest := ReleaseDigest{Since: since, GeneratedAt: now, Releases: []DigestRelease{}}
+
+	for _, r := range releases {
+		name := r.TagName
+		if r.Name.Valid && strings.TrimSpace(r.Name.String) != "" {
+			name = r.Name.String
+		}
+
+		digest.Releases = append(digest.Releases, DigestRelease{
+			Repository:  repoNames[r.RepositoryID],
+			TagName:     r.TagName,
+			Name:        name,
+			Prerelease:  r.Prerelease,
+			PublishedAt: r.PublishedAt.Time,
+			HTMLURL:     r.HTMLURL,
+			Body:        strings.TrimSpace(r.Body.String),
+		})
+	}
+
+	return digest
+}
+
+// Write the digest as Markdown
+func (d ReleaseDigest) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# New releases since %s\n\n", d.Since.Local().Format("2006-01-02 15:04"))
+	if len(d.Releases) == 0 {
+		b.WriteString("No new releases.\n")
+	}
+
+	for _, r := range d.Releases {
+		title := r.TagName
+		if r.Name != r.TagName {
+			title = fmt.Sprintf("%s (%s)", r.Name, r.TagName)
+		}
+		if r.Prerelease {
+			title += " [pre-release]"
+		}
+
+		fmt.Fprintf(&b, "## %s %s\n\n", r.Repository, title)
+		fmt.Fprintf(&b, "Published %s · [Release notes](%s)\n\n", r.PublishedAt.Local().Format("2006-01-02"), r.HTMLURL)
+		if r.Body != "" {
+			b.WriteString(r.Body)
+			b.WriteString("\n\n")
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
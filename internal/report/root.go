@@ -0,0 +1,222 @@
+package report
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Inventory of an organization's repositories for hygiene reviews
+type OrgInventory struct {
+	Org            string         `json:"org"`
+	GeneratedAt    time.Time      `json:"generated_at"`
+	StaleMonths    int            `json:"stale_months"`
+	Total          int            `json:"total"`
+	Visibility     map[string]int `json:"visibility"`
+	Archived       int            `json:"archived"`
+	Forks          int            `json:"forks"`
+	DefaultBranch  map[string]int `json:"default_branches"`
+	Licensed       int            `json:"licensed"`
+	Licenses       map[string]int `json:"licenses"`
+	NoLicense      []string       `json:"no_license"`
+	NoTopics       []string       `json:"no_topics"`
+	NoDescription  []string       `json:"no_description"`
+	Stale          []string       `json:"stale"`
+	LastPushBucket map[string]int `json:"last_push"`
+	Repos          []OrgRepoRow   `json:"repos"`
+}
+
+// One repository's inventory details
+type OrgRepoRow struct {
+	Name           string    `json:"name"`
+	Visibility     string    `json:"visibility"`
+	Archived       bool      `json:"archived"`
+	Fork           bool      `json:"fork"`
+	DefaultBranch  string    `json:"default_branch"`
+	License        string    `json:"license"`
+	Topics         int       `json:"topics"`
+	HasDescription bool      `json:"has_description"`
+	PushedAt       time.Time `json:"pushed_at"`
+	DaysSincePush  int       `json:"days_since_push"`
+	Stale          bool      `json:"stale"`
+}
+
+// Last-push buckets, in display order
+var LastPushBuckets = []string{"< 3 months", "3-12 months", "1-2 years", "> 2 years"}
+
+// Build an inventory from an organization's repositories.
+// Unarchived repositories without a push in staleMonths are listed as stale.
+func BuildOrgInventory(org string, repos []Github.Repository, staleMonths int, now time.Time) OrgInventory {
+	inv := OrgInventory{
+		Org:            org,
+		GeneratedAt:    now,
+		StaleMonths:    staleMonths,
+		Total:          len(repos),
+		Visibility:     map[string]int{},
+		DefaultBranch:  map[string]int{},
+		Licenses:       map[string]int{},
+		LastPushBucket: map[string]int{},
+		NoLicense:      []string{},
+		NoTopics:       []string{},
+		NoDescription:  []string{},
+		Stale:          []string{},
+	}
+	staleBefore := now.AddDate(0, -staleMonths, 0)
+
+	for _, repo := range repos {
+		row := OrgRepoRow{
+			Name:           repo.Name,
+			Visibility:     repoVisibility(repo),
+			Archived:       repo.Archived,
+			Fork:           repo.Fork,
+			DefaultBranch:  repo.DefaultBranch,
+			License:        licenseName(repo.License),
+			Topics:         len(repo.Topics),
+			HasDescription: repo.Description != nil && strings.TrimSpace(*repo.Description) != "",
+			PushedAt:       repo.PushedAt,
+			DaysSincePush:  int(now.Sub(repo.PushedAt).Hours() / 24),
+			Stale:          !repo.Archived && repo.PushedAt.Before(staleBefore),
+		}
+		inv.Repos = append(inv.Repos, row)
+
+		inv.Visibility[row.Visibility]++
+		if row.Archived {
+			inv.Archived++
+		}
+		if row.Fork {
+			inv.Forks++
+		}
+		if row.DefaultBranch != "" {
+			inv.DefaultBranch[row.DefaultBranch]++
+		}
+		if row.License != "" {
+			inv.Licensed++
+			inv.Licenses[row.License]++
+		} else {
+			inv.NoLicense = append(inv.NoLicense, row.Name)
+		}
+		if row.Topics == 0 {
+			inv.NoTopics = append(inv.NoTopics, row.Name)
+		}
+		if !row.HasDescription {
+			inv.NoDescription = append(inv.NoDescription, row.Name)
+		}
+		if row.Stale {
+			inv.Stale = append(inv.Stale, row.Name)
+		}
+		inv.LastPushBucket[lastPushBucket(repo.PushedAt, now)]++
+	}
+
+	sort.Slice(inv.Repos, func(i, j int) bool { return inv.Repos[i].Name < inv.Repos[j].Name })
+	for _, names := range [][]string{inv.NoLicense, inv.NoTopics, inv.NoDescription, inv.Stale} {
+		sort.Strings(names)
+	}
+
+	return inv
+}
+
+// CSV header matching CSVRows
+var OrgRepoCSVHeader = []string{"name", "visibility", "archived", "fork", "default_branch", "license", "topics", "has_description", "pushed_at", "days_since_push", "stale"}
+
+// Flatten repository rows for CSV output
+func (inv OrgInventory) CSVRows() [][]string {
+	rows := make([][]string, len(inv.Repos))
+	for i, r := range inv.Repos {
+		rows[i] = []string{
+			r.Name,
+			r.Visibility,
+			strconv.FormatBool(r.Archived),
+			strconv.FormatBool(r.Fork),
+			r.DefaultBranch,
+			r.License,
+			strconv.Itoa(r.Topics),
+			strconv.FormatBool(r.HasDescription),
+			r.PushedAt.Format(time.RFC3339),
+			strconv.Itoa(r.DaysSincePush),
+			strconv.FormatBool(r.Stale),
+		}
+	}
+
+	return rows
+}
+
+// Sort a count map by count (descending), then key
+func SortedCounts(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// Repository visibility, falling back to the private flag for older API versions
+func repoVisibility(repo Github.Repository) string {
+	if repo.Visibility != "" {
+		return repo.Visibility
+	}
+	if repo.Private {
+		return "private"
+	}
+
+	return "public"
+}
+
+// Short license name, preferring the SPDX ID
+func licenseName(license *Github.RepositoryLicense) string {
+	if license == nil {
+		return ""
+	}
+
+	var spdxID, name string
+	if license.SPDXID != nil {
+		spdxID = *license.SPDXID
+	}
+	if license.Name != nil {
+		name = *license.Name
+	}
+
+	return licenseLabel(spdxID, name)
+}
+
+// Short name of a stored license, like licenseName
+func storedLicenseName(license *Github.RepositoryLicenseModel) string {
+	if license == nil {
+		return ""
+	}
+
+	return licenseLabel(license.SPDXID.String, license.Name.String)
+}
+
+// Prefer the SPDX ID over the license name
+func licenseLabel(spdxID string, name string) string {
+	// Github reports unrecognized licenses as NOASSERTION
+	if spdxID != "" && spdxID != "NOASSERTION" {
+		return spdxID
+	}
+
+	return name
+}
+
+// Bucket a last-push date by age
+func lastPushBucket(pushedAt time.Time, now time.Time) string {
+	switch {
+	case pushedAt.After(now.AddDate(0, -3, 0)):
+		return LastPushBuckets[0]
+	case pushedAt.After(now.AddDate(-1, 0, 0)):
+		return LastPushBuckets[1]
+	case pushedAt.After(now.AddDate(-2, 0, 0)):
+		return LastPushBuckets[2]
+	default:
+		return LastPushBuckets[3]
+	}
+}
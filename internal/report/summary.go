@@ -0,0 +1,157 @@
+package report
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Aggregates over stored repositories
+type StarSummary struct {
+	GeneratedAt   time.Time      `json:"generated_at"`
+	Total         int            `json:"total"`
+	Archived      int            `json:"archived"`
+	Active        int            `json:"active"`
+	Languages     map[string]int `json:"languages"`
+	Licenses      map[string]int `json:"licenses"`
+	Owners        map[string]int `json:"owners"`
+	Topics        map[string]int `json:"topics"`
+	StarredByYear map[string]int `json:"starred_by_year"`
+	CreatedByYear map[string]int `json:"created_by_year"`
+	Sizes         map[string]int `json:"sizes"`
+}
+
+// Label for repositories missing a value, i.e. no license or language
+const Unknown = "(none)"
+
+// Repository size buckets, in display order
+var SizeBuckets = []string{"under 1 MB", "1-10 MB", "10-100 MB", "100 MB-1 GB", "over 1 GB"}
+
+// Build a summary of stored repositories
+func BuildStarSummary(repos []Github.RepositoryModel, now time.Time) StarSummary {
+	s := StarSummary{
+		GeneratedAt:   now,
+		Total:         len(repos),
+		Languages:     map[string]int{},
+		Licenses:      map[string]int{},
+		Owners:        map[string]int{},
+		Topics:        map[string]int{},
+		StarredByYear: map[string]int{},
+		CreatedByYear: map[string]int{},
+		Sizes:         map[string]int{},
+	}
+
+	for _, repo := range repos {
+		if repo.Archived {
+			s.Archived++
+		} else {
+			s.Active++
+		}
+
+		language := Unknown
+		if repo.Language.Valid && repo.Language.String != "" {
+			language = repo.Language.String
+		}
+		s.Languages[language]++
+
+		license := storedLicenseName(repo.License)
+		if license == "" {
+			license = Unknown
+		}
+		s.Licenses[license]++
+
+		owner, _, _ := strings.Cut(repo.FullName, "/")
+		s.Owners[owner]++
+
+		for _, topic := range modelTopics(repo) {
+			s.Topics[topic]++
+		}
+
+		starred := Unknown
+		if repo.StarredAt.Valid {
+			starred = strconv.Itoa(repo.StarredAt.Time.Year())
+		}
+		s.StarredByYear[starred]++
+		if !repo.CreatedAt.IsZero() {
+			s.CreatedByYear[strconv.Itoa(repo.CreatedAt.Year())]++
+		}
+
+		s.Sizes[sizeBucket(repo.Size)]++
+	}
+
+	return s
+}
+
+// Topics of a stored repository, stored as a JSON array
+func modelTopics(repo Github.RepositoryModel) []string {
+	var topics []string
+	if len(repo.Topics) > 0 {
+		_ = json.Unmarshal(repo.Topics, &topics)
+	}
+
+	return topics
+}
+
+// Bucket a repository size, which Github reports in KB
+func sizeBucket(kb int) string {
+	switch {
+	case kb < 1024:
+		return SizeBuckets[0]
+	case kb < 10*1024:
+		return SizeBuckets[1]
+	case kb < 100*1024:
+		return SizeBuckets[2]
+	case kb < 1024*1024:
+		return SizeBuckets[3]
+	default:
+		return SizeBuckets[4]
+	}
+}
+
+// Keys of a by-year count map, oldest first, with Unknown last
+func SortedYears(counts map[string]int) []string {
+	years := make([]string, 0, len(counts))
+	for year := range counts {
+		years = append(years, year)
+	}
+	sort.Slice(years, func(i, j int) bool {
+		if years[i] == Unknown || years[j] == Unknown {
+			return years[j] == Unknown && years[i] != Unknown
+		}
+		return years[i] < years[j]
+	})
+
+	return years
+}
+
+// Counts per calendar month from the month of the earliest time to that of the
+// latest, with the first month. Empty months count 0.
+func MonthlyCounts(times []time.Time) (time.Time, []int) {
+	if len(times) == 0 {
+		return time.Time{}, nil
+	}
+
+	first, last := times[0], times[0]
+	for _, t := range times {
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+
+	monthIndex := func(t time.Time) int {
+		return t.Year()*12 + int(t.Month()) - 1
+	}
+	counts := make([]int, monthIndex(last)-monthIndex(first)+1)
+	for _, t := range times {
+		counts[monthIndex(t)-monthIndex(first)]++
+	}
+
+	return time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, first.Location()), counts
+}
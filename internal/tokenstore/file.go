@@ -0,0 +1,225 @@
+package tokenstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Env var holding an optional passphrase for the encrypted file store
+const PassphraseEnv = "MYGITHUB_STORE_PASSPHRASE"
+
+// File names inside the config directory
+const (
+	credentialsFileName = "credentials.enc"
+	keyFileName         = "credentials.key"
+)
+
+// PBKDF2 iterations for deriving the encryption key
+const kdfIterations = 600_000
+
+// Store tokens in an AES-GCM encrypted file.
+//
+// The encryption key is derived from $MYGITHUB_STORE_PASSPHRASE when set,
+// otherwise from a random key file created next to the credentials file.
+// The key file only protects against casual disclosure (i.e. committing
+// or sharing the credentials file); prefer the keyring or a passphrase.
+type FileStore struct {
+	Path    string
+	KeyPath string
+}
+
+// On-disk format of the encrypted credentials file
+type encryptedFile struct {
+	Salt  []byte `json:"salt"`
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+// Create a new encrypted file store in the config directory
+func NewFileStore(configDir string) *FileStore {
+	return &FileStore{
+		Path:    filepath.Join(configDir, credentialsFileName),
+		KeyPath: filepath.Join(configDir, keyFileName),
+	}
+}
+
+func (s *FileStore) Name() string {
+	return "file"
+}
+
+// Get a token from the credentials file
+func (s *FileStore) Get(account string) (string, error) {
+	tokens, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	token, ok := tokens[account]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return token, nil
+}
+
+// Save a token to the credentials file
+func (s *FileStore) Set(account string, token string) error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	tokens[account] = token
+
+	return s.save(tokens)
+}
+
+// Remove a token from the credentials file
+func (s *FileStore) Delete(account string) error {
+	tokens, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := tokens[account]; !ok {
+		return ErrNotFound
+	}
+	delete(tokens, account)
+
+	return s.save(tokens)
+}
+
+// Read & decrypt all stored tokens
+func (s *FileStore) load() (map[string]string, error) {
+	tokens := map[string]string{}
+
+	raw, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading credentials file: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("error parsing credentials file: %w", err)
+	}
+
+	gcm, err := s.cipher(file.Salt, false)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting credentials file (wrong passphrase?): %w", err)
+	}
+
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("error parsing decrypted credentials: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// Encrypt & write all tokens
+func (s *FileStore) save(tokens map[string]string) error {
+	plaintext, err := json.Marshal(tokens)
+	if err != nil {
+		return fmt.Errorf("error encoding credentials: %w", err)
+	}
+
+	// Fresh salt & nonce on every write
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("error generating salt: %w", err)
+	}
+
+	gcm, err := s.cipher(salt, true)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	raw, err := json.Marshal(encryptedFile{
+		Salt:  salt,
+		Nonce: nonce,
+		Data:  gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding credentials file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return fmt.Errorf("error creating config directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.Path, raw, 0600); err != nil {
+		return fmt.Errorf("error writing credentials file: %w", err)
+	}
+
+	return nil
+}
+
+// Build an AES-GCM cipher from the passphrase or key file
+func (s *FileStore) cipher(salt []byte, create bool) (cipher.AEAD, error) {
+	secret, err := s.secret(create)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pbkdf2.Key(sha256.New, secret, salt, kdfIterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Load the secret used to derive the encryption key
+func (s *FileStore) secret(create bool) (string, error) {
+	if passphrase := os.Getenv(PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	key, err := os.ReadFile(s.KeyPath)
+	if err == nil {
+		return string(key), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) || !create {
+		return "", fmt.Errorf("error reading key file: %w", err)
+	}
+
+	// Generate a new random key file
+	key = make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("error generating key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.KeyPath), 0700); err != nil {
+		return "", fmt.Errorf("error creating config directory: %w", err)
+	}
+	if err := os.WriteFile(s.KeyPath, key, 0600); err != nil {
+		return "", fmt.Errorf("error writing key file: %w", err)
+	}
+
+	return string(key), nil
+}
@@ -0,0 +1,44 @@
+package tokenstore
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Store tokens in the OS keyring (Secret Service, macOS Keychain, Windows Credential Manager)
+type KeyringStore struct{}
+
+// Create a new OS keyring store
+func NewKeyringStore() *KeyringStore {
+	return &KeyringStore{}
+}
+
+func (s *KeyringStore) Name() string {
+	return "keyring"
+}
+
+// Get a token from the keyring
+func (s *KeyringStore) Get(account string) (string, error) {
+	token, err := keyring.Get(serviceName, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+
+	return token, err
+}
+
+// Save a token to the keyring
+func (s *KeyringStore) Set(account string, token string) error {
+	return keyring.Set(serviceName, account, token)
+}
+
+// Remove a token from the keyring
+func (s *KeyringStore) Delete(account string) error {
+	err := keyring.Delete(serviceName, account)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
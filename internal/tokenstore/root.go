@@ -0,0 +1,112 @@
+/* Persistent storage for Github access tokens. */
+package tokenstore
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Returned when no token is stored for an account
+var ErrNotFound = errors.New("token not found")
+
+// Service name tokens are stored under
+const serviceName = "mygithub"
+
+// Store saves, loads & removes access tokens by account name
+type Store interface {
+	// Human-readable name of the backend, i.e. "keyring"
+	Name() string
+	Get(account string) (string, error)
+	Set(account string, token string) error
+	Delete(account string) error
+}
+
+// Create a token store by backend name ("auto", "keyring", or "file").
+//
+// The "auto" backend uses the OS keyring and falls back to the
+// encrypted file when the keyring is unavailable.
+func New(backend string, configDir string) (Store, error) {
+	switch backend {
+	case "", "auto":
+		return &fallbackStore{
+			primary:   NewKeyringStore(),
+			secondary: NewFileStore(configDir),
+		}, nil
+	case "keyring":
+		return NewKeyringStore(), nil
+	case "file":
+		return NewFileStore(configDir), nil
+	default:
+		return nil, fmt.Errorf("unknown token store %q (expected auto, keyring, or file)", backend)
+	}
+}
+
+// Try the primary store, using the secondary if the primary fails
+type fallbackStore struct {
+	primary   Store
+	secondary Store
+}
+
+func (s *fallbackStore) Name() string {
+	return s.primary.Name() + "+" + s.secondary.Name()
+}
+
+// Get a token from the first store that has one
+func (s *fallbackStore) Get(account string) (string, error) {
+	token, err := s.primary.Get(account)
+	if err == nil {
+		return token, nil
+	}
+
+	return s.secondary.Get(account)
+}
+
+// Save token to the primary store, or the secondary if the primary is unavailable
+func (s *fallbackStore) Set(account string, token string) error {
+	if err := s.primary.Set(account, token); err != nil {
+		if err2 := s.secondary.Set(account, token); err2 != nil {
+			return fmt.Errorf("%s: %v; %s: %w", s.primary.Name(), err, s.secondary.Name(), err2)
+		}
+	}
+
+	return nil
+}
+
+// Remove token from both stores
+func (s *fallbackStore) Delete(account string) error {
+	errPrimary := s.primary.Delete(account)
+	errSecondary := s.secondary.Delete(account)
+
+	// Removed from at least one store
+	if errPrimary == nil || errSecondary == nil {
+		return nil
+	}
+	if errors.Is(errPrimary, ErrNotFound) && errors.Is(errSecondary, ErrNotFound) {
+		return ErrNotFound
+	}
+
+	return fmt.Errorf("%s: %v; %s: %w", s.primary.Name(), errPrimary, s.secondary.Name(), errSecondary)
+}
+
+// Get a token for an account & the name of the backend that holds it.
+// Returns ErrNotFound if no backend has one.
+func Lookup(store Store, account string) (string, string, error) {
+	if fb, ok := store.(*fallbackStore); ok {
+		if token, err := fb.primary.Get(account); err == nil {
+			return token, fb.primary.Name(), nil
+		}
+		// The primary being unavailable is why there's a fallback, so only the secondary's error counts
+		token, err := fb.secondary.Get(account)
+		if err != nil {
+			return "", "", err
+		}
+		return token, fb.secondary.Name(), nil
+	}
+
+	token, err := store.Get(account)
+	if err != nil {
+		return "", "", err
+	}
+
+	return token, store.Name(), nil
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Score how well query fuzzy-matches s: every query character must appear in
+// s in order. Consecutive characters & matches at word starts score higher.
+// Returns false if query doesn't match.
+func fuzzyScore(query string, s string) (int, bool) {
+	if query == "" {
+		return 0, true
+	}
+
+	q := []rune(strings.ToLower(query))
+	text := []rune(s)
+
+	score, qi, streak := 0, 0, 0
+	for i, r := range text {
+		if qi == len(q) {
+			break
+		}
+		if unicode.ToLower(r) != q[qi] {
+			streak = 0
+			continue
+		}
+
+		score++
+		// Runs of matching characters
+		streak++
+		score += streak * 2
+		// Start of a word, i.e. after "/", "-" or a space
+		if i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1]) {
+			score += 5
+		}
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+
+	// Prefer shorter strings for equal matches
+	return score*100 - min(len(text), 99), true
+}
+
+// Best fuzzy score of query over each space-separated term against any of fields.
+// Every term must match some field.
+func matchRepo(query string, fields []string) (int, bool) {
+	total := 0
+	for _, term := range strings.Fields(query) {
+		best, found := 0, false
+		for i, field := range fields {
+			score, ok := fuzzyScore(term, field)
+			if !ok {
+				continue
+			}
+			// Earlier fields, i.e. the name, weigh more
+			score = score * (len(fields) - i)
+			if !found || score > best {
+				best, found = score, true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+		total += best
+	}
+
+	return total, true
+}
@@ -0,0 +1,486 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/redjax/go-mygithub/internal/domain/Github"
+)
+
+// Actions the TUI can't do on its own
+type Options struct {
+	// Unstar a repository by full name, nil to disable unstarring
+	Unstar func(fullName string) error
+}
+
+// Sort orders, cycled with "s"
+const (
+	SortStarred = "starred"
+	SortStars   = "stars"
+	SortPushed  = "pushed"
+	SortName    = "name"
+)
+
+var sortOrders = []string{SortStarred, SortStars, SortPushed, SortName}
+
+// Styles use attributes only, no colors, so they work on any terminal
+var (
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	faintStyle    = lipgloss.NewStyle().Faint(true)
+)
+
+const helpText = "↑/↓ move  / search  s sort  r reverse  o open  u unstar  q quit"
+
+// Browse repositories in a full-screen terminal UI until the user quits
+func Run(repos []Github.Repository, opts Options) error {
+	m := newModel(repos, opts)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return fmt.Errorf("error running TUI: %w", err)
+	}
+
+	return nil
+}
+
+// TUI state
+type model struct {
+	opts    Options
+	repos   []Github.Repository
+	visible []int // indexes into repos, filtered & sorted
+
+	cursor int
+	offset int // first visible list row
+
+	query     string
+	searching bool
+
+	sortIdx int
+	reverse bool
+
+	confirming bool // waiting for "y" to unstar the selected repository
+	status     string
+
+	width  int
+	height int
+}
+
+// Result of opening a URL or unstarring
+type statusMsg string
+
+type unstarredMsg struct {
+	fullName string
+	err      error
+}
+
+func newModel(repos []Github.Repository, opts Options) *model {
+	m := &model{opts: opts, repos: repos, width: 80, height: 24}
+	m.refresh()
+
+	return m
+}
+
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+
+	case statusMsg:
+		m.status = string(msg)
+
+	case unstarredMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Error unstarring %s: %v", msg.fullName, msg.err)
+			break
+		}
+		m.status = fmt.Sprintf("Unstarred %s", msg.fullName)
+		for i, repo := range m.repos {
+			if repo.FullName == msg.fullName {
+				m.repos = append(m.repos[:i], m.repos[i+1:]...)
+				break
+			}
+		}
+		// Indexes in visible are stale, keep the cursor position instead
+		cursor := m.cursor
+		m.visible = nil
+		m.refresh()
+		m.cursor = cursor
+		m.scroll()
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+
+	// Unstar confirmation
+	if m.confirming {
+		m.confirming = false
+		repo, ok := m.selected()
+		if msg.String() != "y" || !ok {
+			m.status = "Unstar cancelled"
+			return m, nil
+		}
+		m.status = fmt.Sprintf("Unstarring %s...", repo.FullName)
+		return m, m.unstar(repo.FullName)
+	}
+
+	// Typing a search query
+	if m.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.searching = false
+		case tea.KeyEsc:
+			m.searching = false
+			m.query = ""
+			m.refresh()
+		case tea.KeyBackspace:
+			if r := []rune(m.query); len(r) > 0 {
+				m.query = string(r[:len(r)-1])
+				m.refresh()
+			}
+		case tea.KeyCtrlU:
+			m.query = ""
+			m.refresh()
+		case tea.KeyUp, tea.KeyDown, tea.KeyPgUp, tea.KeyPgDown:
+			m.move(msg.String())
+		case tea.KeyRunes, tea.KeySpace:
+			m.query += string(msg.Runes)
+			m.refresh()
+		}
+		return m, nil
+	}
+
+	m.status = ""
+	switch key := msg.String(); key {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if m.query != "" {
+			m.query = ""
+			m.refresh()
+		}
+	case "/":
+		m.searching = true
+	case "s":
+		m.sortIdx = (m.sortIdx + 1) % len(sortOrders)
+		m.refresh()
+	case "r":
+		m.reverse = !m.reverse
+		m.refresh()
+	case "o", "enter":
+		if repo, ok := m.selected(); ok {
+			m.status = fmt.Sprintf("Opening %s...", repo.HTMLURL)
+			return m, openURL(repo.HTMLURL)
+		}
+	case "u":
+		if m.opts.Unstar == nil {
+			m.status = "Unstarring isn't available"
+		} else if repo, ok := m.selected(); ok {
+			m.confirming = true
+			m.status = fmt.Sprintf("Unstar %s? (y/n)", repo.FullName)
+		}
+	default:
+		m.move(key)
+	}
+
+	return m, nil
+}
+
+// Move the cursor for a navigation key
+func (m *model) move(key string) {
+	page := max(m.listHeight()-1, 1)
+
+	switch key {
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "pgup", "ctrl+b":
+		m.cursor -= page
+	case "pgdown", "ctrl+f":
+		m.cursor += page
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = len(m.visible) - 1
+	default:
+		return
+	}
+
+	m.scroll()
+}
+
+// Clamp the cursor & scroll it into view
+func (m *model) scroll() {
+	m.cursor = max(min(m.cursor, len(m.visible)-1), 0)
+
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(min(m.offset, len(m.visible)-height), 0)
+}
+
+// Filter by the search query & sort, keeping the selected repository if it's still visible
+func (m *model) refresh() {
+	current, hadSelection := m.selected()
+
+	scores := map[int]int{}
+	m.visible = m.visible[:0]
+	for i, repo := range m.repos {
+		score, ok := matchRepo(m.query, searchFields(repo))
+		if !ok {
+			continue
+		}
+		scores[i] = score
+		m.visible = append(m.visible, i)
+	}
+
+	order := sortOrders[m.sortIdx]
+	sort.SliceStable(m.visible, func(a, b int) bool {
+		i, j := m.visible[a], m.visible[b]
+		// Best matches first while searching
+		if m.query != "" && scores[i] != scores[j] {
+			return scores[i] > scores[j]
+		}
+		if m.reverse {
+			return repoLess(m.repos[j], m.repos[i], order)
+		}
+		return repoLess(m.repos[i], m.repos[j], order)
+	})
+
+	m.cursor = 0
+	if hadSelection {
+		for pos, i := range m.visible {
+			if m.repos[i].FullName == current.FullName {
+				m.cursor = pos
+				break
+			}
+		}
+	}
+	m.scroll()
+}
+
+// Fields a search matches against, most important first
+func searchFields(repo Github.Repository) []string {
+	fields := []string{repo.FullName}
+	if repo.Language != nil {
+		fields = append(fields, *repo.Language)
+	}
+	fields = append(fields, strings.Join(repo.Topics, " "))
+	if repo.Description != nil {
+		fields = append(fields, *repo.Description)
+	}
+
+	return fields
+}
+
+// Default order of a sort: newest or most starred first, names A-Z
+func repoLess(a Github.Repository, b Github.Repository, order string) bool {
+	switch order {
+	case SortStars:
+		if a.StargazersCount != b.StargazersCount {
+			return a.StargazersCount > b.StargazersCount
+		}
+	case SortPushed:
+		if !a.PushedAt.Equal(b.PushedAt) {
+			return a.PushedAt.After(b.PushedAt)
+		}
+	case SortStarred:
+		at, bt := starredTime(a), starredTime(b)
+		if !at.Equal(bt) {
+			return at.After(bt)
+		}
+	}
+
+	return strings.ToLower(a.FullName) < strings.ToLower(b.FullName)
+}
+
+func starredTime(repo Github.Repository) time.Time {
+	if repo.StarredAt == nil {
+		return time.Time{}
+	}
+
+	return *repo.StarredAt
+}
+
+func (m *model) selected() (Github.Repository, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return Github.Repository{}, false
+	}
+
+	return m.repos[m.visible[m.cursor]], true
+}
+
+// Rows available to the list, between the header & footer
+func (m *model) listHeight() int {
+	return max(m.height-2, 1)
+}
+
+func (m *model) View() string {
+	height := m.listHeight()
+	listWidth := min(max(m.width*2/5, 30), m.width)
+	detailWidth := max(m.width-listWidth-3, 0)
+
+	// Header
+	order := sortOrders[m.sortIdx]
+	if m.reverse {
+		order += " (reversed)"
+	}
+	header := fmt.Sprintf("mygithub  %d/%d starred  sort: %s", len(m.visible), len(m.repos), order)
+	if m.searching || m.query != "" {
+		header += "  search: " + m.query
+		if m.searching {
+			header += "_"
+		}
+	}
+
+	// Repository list
+	rows := make([]string, 0, height)
+	for pos := m.offset; pos < len(m.visible) && len(rows) < height; pos++ {
+		repo := m.repos[m.visible[pos]]
+		stars := fmt.Sprintf(" %d", repo.StargazersCount)
+		name := ansi.Truncate(repo.FullName, listWidth-len(stars), "…")
+		row := name + strings.Repeat(" ", max(listWidth-ansi.StringWidth(name)-len(stars), 0)) + stars
+		if pos == m.cursor {
+			row = selectedStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+	if len(m.visible) == 0 {
+		rows = append(rows, faintStyle.Render("No matching repositories"))
+	}
+	list := lipgloss.NewStyle().Width(listWidth).Height(height).MaxHeight(height).Render(strings.Join(rows, "\n"))
+
+	body := list
+	if detailWidth > 0 {
+		detail := ""
+		if repo, ok := m.selected(); ok {
+			detail = renderDetail(repo, detailWidth)
+		}
+		detail = lipgloss.NewStyle().Width(detailWidth).Height(height).MaxHeight(height).Render(detail)
+		separator := strings.TrimSuffix(strings.Repeat(" │ \n", height), "\n")
+		body = lipgloss.JoinHorizontal(lipgloss.Top, list, separator, detail)
+	}
+
+	// Footer
+	footer := faintStyle.Render(helpText)
+	if m.status != "" {
+		footer = m.status
+	}
+
+	return titleStyle.Render(ansi.Truncate(header, m.width, "…")) + "\n" + body + "\n" + ansi.Truncate(footer, m.width, "…")
+}
+
+// Details of a repository, wrapped to width
+func renderDetail(repo Github.Repository, width int) string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render(repo.FullName) + "\n")
+	var flags []string
+	if repo.Archived {
+		flags = append(flags, "archived")
+	}
+	if repo.Fork {
+		flags = append(flags, "fork")
+	}
+	if len(flags) > 0 {
+		b.WriteString(faintStyle.Render(strings.Join(flags, ", ")) + "\n")
+	}
+	if repo.Description != nil && *repo.Description != "" {
+		b.WriteString("\n" + *repo.Description + "\n")
+	}
+	if len(repo.Topics) > 0 {
+		b.WriteString("\n" + faintStyle.Render(strings.Join(repo.Topics, ", ")) + "\n")
+	}
+	b.WriteString("\n")
+
+	field := func(name string, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%-12s %s\n", name, value)
+		}
+	}
+	date := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Local().Format("2006-01-02")
+	}
+
+	owner := repo.Owner.Login
+	if repo.Owner.Type != "" {
+		owner += " (" + strings.ToLower(repo.Owner.Type) + ")"
+	}
+	field("Owner", owner)
+	if repo.Language != nil {
+		field("Language", *repo.Language)
+	}
+	license := "none"
+	if repo.License != nil && repo.License.Name != nil {
+		license = *repo.License.Name
+	}
+	field("License", license)
+	field("Stars", fmt.Sprintf("%d", repo.StargazersCount))
+	field("Forks", fmt.Sprintf("%d", repo.ForksCount))
+	field("Open issues", fmt.Sprintf("%d", repo.OpenIssuesCount))
+	field("Created", date(repo.CreatedAt))
+	field("Pushed", date(repo.PushedAt))
+	field("Starred", date(starredTime(repo)))
+	if repo.Homepage != nil {
+		field("Homepage", *repo.Homepage)
+	}
+	field("URL", repo.HTMLURL)
+
+	return lipgloss.NewStyle().Width(width).Render(b.String())
+}
+
+// Open a URL in the default browser
+func openURL(url string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+
+		if err := cmd.Start(); err != nil {
+			return statusMsg(fmt.Sprintf("Error opening browser: %v", err))
+		}
+		go cmd.Wait()
+
+		return statusMsg(fmt.Sprintf("Opened %s", url))
+	}
+}
+
+// Unstar a repository in the background
+func (m *model) unstar(fullName string) tea.Cmd {
+	unstar := m.opts.Unstar
+	return func() tea.Msg {
+		return unstarredMsg{fullName: fullName, err: unstar(fullName)}
+	}
+}